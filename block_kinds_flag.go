@@ -0,0 +1,44 @@
+package newlineafterblock
+
+import (
+	"fmt"
+	"slices"
+	"strings"
+)
+
+// blockKinds lists the block kinds as returned by blockKind, which are accepted
+// by the -block-kinds and -min-block-stmts flags.
+var blockKinds = []string{"if", "for", "range", "switch", "select", "func"}
+
+// blockKindSet is a custom flag type that holds the block kinds the after-block
+// rule applies to. If it is never set, all block kinds are enabled.
+type blockKindSet struct {
+	kinds []string
+}
+
+// String returns a string representation of the enabled block kinds.
+func (b *blockKindSet) String() string {
+	return strings.Join(b.kinds, ",")
+}
+
+// Set parses a comma separated list of block kinds and adds them to the
+// enabled block kinds.
+func (b *blockKindSet) Set(value string) error {
+	var kinds []string
+	for kind := range strings.SplitSeq(value, ",") {
+		kind = strings.TrimSpace(kind)
+		if !slices.Contains(blockKinds, kind) {
+			return fmt.Errorf("invalid block kind %q: expected one of %s", kind, strings.Join(blockKinds, ", "))
+		}
+
+		kinds = append(kinds, kind)
+	}
+
+	b.kinds = append(b.kinds, kinds...)
+	return nil
+}
+
+// enabled checks if the after-block rule applies to the given block kind.
+func (b *blockKindSet) enabled(kind string) bool {
+	return len(b.kinds) == 0 || slices.Contains(b.kinds, kind)
+}
@@ -1,12 +1,139 @@
 // Command newline-after-block is a linter that checks for newlines after block statements.
+//
+// Flags can also be provided in the NEWLINEAFTERBLOCK_FLAGS environment
+// variable, e.g. NEWLINEAFTERBLOCK_FLAGS="-normalize -exclude '_test\.go$'".
+// They are applied before the command-line flags, which take precedence.
+//
+// Built with the multichecker build tag, the command runs newline-after-block
+// together with the companion analyzers in a single binary, e.g.
+// go build -tags multichecker ./cmd/newline-after-block. The flags of each
+// analyzer are then prefixed with its name, e.g. -newlineafterblock.normalize,
+// while NEWLINEAFTERBLOCK_FLAGS keeps using the names without prefix.
 package main
 
 import (
-	"golang.org/x/tools/go/analysis/singlechecker"
+	"errors"
+	"flag"
+	"fmt"
+	"io"
+	"os"
+	"strings"
+
+	"golang.org/x/tools/go/analysis"
 
 	newlineafterblock "github.com/breml/newline-after-block"
 )
 
+// envFlags is the environment variable holding additional flags.
+const envFlags = "NEWLINEAFTERBLOCK_FLAGS"
+
 func main() {
-	singlechecker.Main(newlineafterblock.New())
+	analyzer := newlineafterblock.New()
+
+	err := applyEnvFlags(analyzer)
+	if err != nil {
+		fmt.Fprintf(os.Stderr, "newline-after-block: %v\n", err)
+		os.Exit(1)
+	}
+
+	runChecker(analyzer)
+}
+
+// applyEnvFlags sets the analyzer flags found in the NEWLINEAFTERBLOCK_FLAGS
+// environment variable.
+func applyEnvFlags(analyzer *analysis.Analyzer) error {
+	value := os.Getenv(envFlags)
+	if strings.TrimSpace(value) == "" {
+		return nil
+	}
+
+	args, err := splitFlags(value)
+	if err != nil {
+		return fmt.Errorf("invalid %s: %w", envFlags, err)
+	}
+
+	// Parse into a separate flag set sharing the flag values, so errors are
+	// returned instead of printing the usage.
+	fs := flag.NewFlagSet(envFlags, flag.ContinueOnError)
+	fs.SetOutput(io.Discard)
+	analyzer.Flags.VisitAll(func(f *flag.Flag) {
+		fs.Var(f.Value, f.Name, f.Usage)
+	})
+
+	err = fs.Parse(args)
+	if err != nil {
+		return fmt.Errorf("invalid %s: %w", envFlags, err)
+	}
+
+	if fs.NArg() > 0 {
+		return fmt.Errorf("invalid %s: unexpected argument %q", envFlags, fs.Arg(0))
+	}
+
+	return nil
+}
+
+// splitFlags splits a string into arguments like a shell would, supporting
+// single quotes, double quotes and backslash escapes. Within double quotes a
+// backslash only escapes a double quote or another backslash.
+func splitFlags(value string) ([]string, error) {
+	var (
+		args    []string
+		current strings.Builder
+		inArg   bool
+		quote   rune
+		escaped bool
+	)
+
+	for _, r := range value {
+		switch {
+		case escaped:
+			if quote == '"' && r != '"' && r != '\\' {
+				current.WriteRune('\\')
+			}
+
+			current.WriteRune(r)
+			escaped = false
+
+		case r == '\\' && quote != '\'':
+			escaped = true
+			inArg = true
+
+		case quote != 0:
+			if r == quote {
+				quote = 0
+				continue
+			}
+
+			current.WriteRune(r)
+
+		case r == '\'' || r == '"':
+			quote = r
+			inArg = true
+
+		case r == ' ' || r == '\t' || r == '\n':
+			if inArg {
+				args = append(args, current.String())
+				current.Reset()
+				inArg = false
+			}
+
+		default:
+			current.WriteRune(r)
+			inArg = true
+		}
+	}
+
+	if escaped {
+		return nil, errors.New("trailing backslash")
+	}
+
+	if quote != 0 {
+		return nil, fmt.Errorf("unterminated %c quote", quote)
+	}
+
+	if inArg {
+		args = append(args, current.String())
+	}
+
+	return args, nil
 }
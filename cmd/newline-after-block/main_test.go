@@ -0,0 +1,97 @@
+package main
+
+import (
+	"slices"
+	"testing"
+
+	newlineafterblock "github.com/breml/newline-after-block"
+)
+
+func TestApplyEnvFlags(t *testing.T) {
+	t.Setenv(envFlags, `-normalize -exclude '_test\.go$' -e "generated .*\.go"`)
+
+	analyzer := newlineafterblock.New()
+
+	err := applyEnvFlags(analyzer)
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	if got := analyzer.Flags.Lookup("normalize").Value.String(); got != "true" {
+		t.Errorf("normalize = %q, want %q", got, "true")
+	}
+
+	want := `_test\.go$,generated .*\.go`
+	if got := analyzer.Flags.Lookup("exclude").Value.String(); got != want {
+		t.Errorf("exclude = %q, want %q", got, want)
+	}
+}
+
+func TestApplyEnvFlagsCommandLineTakesPrecedence(t *testing.T) {
+	t.Setenv(envFlags, "-normalize")
+
+	analyzer := newlineafterblock.New()
+
+	err := applyEnvFlags(analyzer)
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	err = analyzer.Flags.Parse([]string{"-normalize=false"})
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	if got := analyzer.Flags.Lookup("normalize").Value.String(); got != "false" {
+		t.Errorf("normalize = %q, want %q", got, "false")
+	}
+}
+
+func TestApplyEnvFlagsErrors(t *testing.T) {
+	tests := []string{
+		"-unknown-flag",
+		"-normalize ./...",
+		"-exclude 'unterminated",
+		`-exclude \`,
+	}
+
+	for _, value := range tests {
+		t.Run(value, func(t *testing.T) {
+			t.Setenv(envFlags, value)
+
+			err := applyEnvFlags(newlineafterblock.New())
+			if err == nil {
+				t.Fatalf("expected error for %s=%q", envFlags, value)
+			}
+		})
+	}
+}
+
+func TestSplitFlags(t *testing.T) {
+	tests := []struct {
+		value string
+		want  []string
+	}{
+		{value: "", want: nil},
+		{value: "  -a   -b  ", want: []string{"-a", "-b"}},
+		{value: `-e 'a b' -e "c d"`, want: []string{"-e", "a b", "-e", "c d"}},
+		{value: `-e a\ b`, want: []string{"-e", "a b"}},
+		{value: `-e 'a\.go'`, want: []string{"-e", `a\.go`}},
+		{value: `-e "a\.go"`, want: []string{"-e", `a\.go`}},
+		{value: `-e "a\"b"`, want: []string{"-e", `a"b`}},
+		{value: `-e ""`, want: []string{"-e", ""}},
+	}
+
+	for _, tc := range tests {
+		t.Run(tc.value, func(t *testing.T) {
+			got, err := splitFlags(tc.value)
+			if err != nil {
+				t.Fatalf("unexpected error: %v", err)
+			}
+
+			if !slices.Equal(got, tc.want) {
+				t.Errorf("splitFlags(%q) = %q, want %q", tc.value, got, tc.want)
+			}
+		})
+	}
+}
@@ -0,0 +1,26 @@
+//go:build multichecker
+
+package main
+
+import (
+	"golang.org/x/tools/go/analysis"
+	"golang.org/x/tools/go/analysis/multichecker"
+	"golang.org/x/tools/go/analysis/passes/defers"
+)
+
+// companions are the analyzers run together with newline-after-block. Add
+// related analyzers here to run them in the same binary. The defers analyzer
+// reports mistakes in the defer statements newline-after-block spaces.
+var companions = []*analysis.Analyzer{
+	defers.Analyzer,
+}
+
+// runChecker runs the analyzer together with its companions.
+func runChecker(analyzer *analysis.Analyzer) {
+	multichecker.Main(analyzers(analyzer)...)
+}
+
+// analyzers returns the analyzer followed by its companions.
+func analyzers(analyzer *analysis.Analyzer) []*analysis.Analyzer {
+	return append([]*analysis.Analyzer{analyzer}, companions...)
+}
@@ -0,0 +1,25 @@
+//go:build multichecker
+
+package main
+
+import (
+	"testing"
+
+	"golang.org/x/tools/go/analysis"
+
+	newlineafterblock "github.com/breml/newline-after-block"
+)
+
+func TestAnalyzers(t *testing.T) {
+	analyzer := newlineafterblock.New()
+
+	got := analyzers(analyzer)
+	if len(got) != len(companions)+1 || got[0] != analyzer {
+		t.Fatalf("analyzers() = %v, want the analyzer followed by %d companions", got, len(companions))
+	}
+
+	err := analysis.Validate(got)
+	if err != nil {
+		t.Fatalf("invalid analyzers: %v", err)
+	}
+}
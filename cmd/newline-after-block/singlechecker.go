@@ -0,0 +1,13 @@
+//go:build !multichecker
+
+package main
+
+import (
+	"golang.org/x/tools/go/analysis"
+	"golang.org/x/tools/go/analysis/singlechecker"
+)
+
+// runChecker runs the analyzer as a standalone linter.
+func runChecker(analyzer *analysis.Analyzer) {
+	singlechecker.Main(analyzer)
+}
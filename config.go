@@ -0,0 +1,125 @@
+package newlineafterblock
+
+import (
+	"errors"
+	"slices"
+	"strconv"
+	"strings"
+)
+
+// Config holds the configuration of the analyzer, as an alternative to setting
+// its flags, e.g. if the analyzer is embedded in another tool. Each field
+// corresponds to the flag named in its comment. The zero value matches the
+// flag defaults.
+type Config struct {
+	// ExcludePatterns are regex patterns to exclude files from analysis (-exclude).
+	ExcludePatterns []string
+	// NoExclude ignores all exclude patterns (-no-exclude).
+	NoExclude bool
+	// CompactFilePatterns are regex patterns for compact files (-compact-files).
+	CompactFilePatterns []string
+	// RelaxMainInit relaxes main and init functions like compact files (-relax-main-init).
+	RelaxMainInit bool
+	// ExportedOnly only analyzes exported functions (-exported-only).
+	ExportedOnly bool
+	// Normalize is an alias of MaxBlankLines 1, which it does not override (-normalize).
+	Normalize bool
+	// MaxBlankLines is the maximum number of blank lines after blocks, 0 disables the check (-max-blank-lines).
+	MaxBlankLines int
+	// DeferExceptionAnyGuard allows defer after any guard if (-defer-exception-any-guard).
+	DeferExceptionAnyGuard bool
+	// CheckTrailingFuncLitArgs checks calls with a trailing function literal (-check-trailing-funclit-args).
+	CheckTrailingFuncLitArgs bool
+	// BareBlocks checks bare blocks used for scoping (-bare-blocks).
+	BareBlocks bool
+	// SelectClauseBodies checks the statements inside select clauses (-select-clause-bodies).
+	SelectClauseBodies bool
+	// LastCaseBlock checks blocks ending the last case of a switch (-last-case-block).
+	LastCaseBlock bool
+	// BlankAfterGoto checks goto statements (-blank-after-goto).
+	BlankAfterGoto bool
+	// DeferGroupByComment starts a new group of defers at a comment (-defer-group-by-comment).
+	DeferGroupByComment bool
+	// StrictDefer disables the defer exceptions (-strict-defer).
+	StrictDefer bool
+	// StrictGuardSpacing always checks guard if statements (-strict-guard-spacing).
+	StrictGuardSpacing bool
+	// AllowGoDeferAdjacent allows adjacent go and defer statements (-allow-go-defer-adjacent).
+	AllowGoDeferAdjacent bool
+	// NamedFuncLitOnly only checks function literals assigned to a named variable (-named-funclit-only).
+	NamedFuncLitOnly bool
+	// FlagTypeDecls checks multi-line type declarations inside functions (-flag-type-decls).
+	FlagTypeDecls bool
+	// FlagSameLineStatement reports statements on the closing brace line (-flag-same-line-statement).
+	FlagSameLineStatement bool
+	// DisableCaseClauses disables the spacing check between case blocks (-case-clauses=false).
+	DisableCaseClauses bool
+	// AllowCaseComments allows comments directly before the next case (-check-case-comments=false).
+	AllowCaseComments bool
+	// CaseConsistency only reports mixed spacing between case blocks (-case-consistency).
+	CaseConsistency bool
+	// MultilineBlocksOnly skips one-line blocks (-multiline-blocks-only).
+	MultilineBlocksOnly bool
+	// RequireBefore also requires a blank line before blocks (-require-before).
+	RequireBefore bool
+	// Message is a template for the messages of missing blank lines (-message).
+	Message string
+	// ReportAtNext reports missing blank lines at the following line (-report-at-next).
+	ReportAtNext bool
+	// ReportOncePerBlock reports at most one diagnostic per block end (-report-once-per-block).
+	ReportOncePerBlock bool
+	// BlockKinds are the block kinds the after-block rule applies to, all if empty (-block-kinds).
+	BlockKinds []string
+	// MinBlockStmts are the per kind minimum numbers of body statements (-min-block-stmts).
+	MinBlockStmts map[string]int
+}
+
+// DefaultConfig returns the configuration used by New, which matches the
+// defaults of the flags.
+func DefaultConfig() Config {
+	return Config{}
+}
+
+// applyConfig applies the values of cfg which are not plain booleans, these
+// are validated like the corresponding flags.
+func (n *newlineafterblock) applyConfig(cfg Config) error {
+	var errs []error
+
+	for _, pattern := range cfg.ExcludePatterns {
+		errs = append(errs, n.exclude.Set(pattern))
+	}
+
+	for _, pattern := range cfg.CompactFilePatterns {
+		errs = append(errs, n.compact.Set(pattern))
+	}
+
+	if cfg.Message != "" {
+		errs = append(errs, n.message.Set(cfg.Message))
+	}
+
+	if len(cfg.BlockKinds) > 0 {
+		errs = append(errs, n.blockKinds.Set(strings.Join(cfg.BlockKinds, ",")))
+	}
+
+	if len(cfg.MinBlockStmts) > 0 {
+		specs := make([]string, 0, len(cfg.MinBlockStmts))
+		for kind, count := range cfg.MinBlockStmts {
+			specs = append(specs, kind+"="+strconv.Itoa(count))
+		}
+
+		slices.Sort(specs)
+		errs = append(errs, n.minBlockStmts.Set(strings.Join(specs, ",")))
+	}
+
+	return errors.Join(errs...)
+}
+
+// maxBlankLines returns the maximum number of blank lines after blocks, with
+// Normalize as alias of MaxBlankLines 1.
+func (cfg Config) maxBlankLines() int {
+	if cfg.MaxBlankLines == 0 && cfg.Normalize {
+		return 1
+	}
+
+	return cfg.MaxBlankLines
+}
@@ -0,0 +1,71 @@
+package newlineafterblock
+
+import (
+	"fmt"
+	"strings"
+	"text/template"
+)
+
+// messageData holds the values available in a message template.
+type messageData struct {
+	// Kind is the kind of statement the missing blank line follows, e.g. "if".
+	Kind string
+}
+
+// messageTemplate is a custom flag type that holds a template for the messages
+// of all diagnostics. If it is never set, the default messages are used.
+type messageTemplate struct {
+	tmpl *template.Template
+	raw  string
+}
+
+// String returns the raw message template.
+func (m *messageTemplate) String() string {
+	return m.raw
+}
+
+// Set parses a message template, validating it against the available fields.
+func (m *messageTemplate) Set(value string) error {
+	tmpl, err := template.New("message").Option("missingkey=error").Parse(value)
+	if err != nil {
+		return fmt.Errorf("invalid message template %q: %w", value, err)
+	}
+
+	err = tmpl.Execute(&strings.Builder{}, messageData{Kind: "if"})
+	if err != nil {
+		return fmt.Errorf("invalid message template %q: %w", value, err)
+	}
+
+	m.tmpl = tmpl
+	m.raw = value
+	return nil
+}
+
+// format returns the message for the given statement kind, or defaultMessage
+// if no template is set.
+func (m *messageTemplate) format(kind, defaultMessage string) string {
+	if m.tmpl == nil {
+		return defaultMessage
+	}
+
+	var message strings.Builder
+
+	err := m.tmpl.Execute(&message, messageData{Kind: kind})
+	if err != nil {
+		return defaultMessage
+	}
+
+	return message.String()
+}
+
+// formatDetailed returns the message for the given statement kind followed by
+// defaultMessage, or only defaultMessage if no template is set. It is used for
+// the diagnostics other than missing blank lines after blocks and between case
+// blocks, which stay distinguishable this way.
+func (m *messageTemplate) formatDetailed(kind, defaultMessage string) string {
+	if m.tmpl == nil {
+		return defaultMessage
+	}
+
+	return m.format(kind, defaultMessage) + ": " + defaultMessage
+}
@@ -0,0 +1,69 @@
+package newlineafterblock
+
+import (
+	"fmt"
+	"maps"
+	"slices"
+	"strconv"
+	"strings"
+)
+
+// minBlockStmts is a custom flag type that holds the minimum number of body
+// statements per block kind for which a blank line after the block is required.
+type minBlockStmts struct {
+	thresholds map[string]int
+}
+
+// String returns a string representation of the thresholds, sorted by kind.
+func (m *minBlockStmts) String() string {
+	kinds := make([]string, 0, len(m.thresholds))
+	for kind := range m.thresholds {
+		kinds = append(kinds, kind)
+	}
+
+	slices.Sort(kinds)
+
+	specs := make([]string, 0, len(kinds))
+	for _, kind := range kinds {
+		specs = append(specs, kind+"="+strconv.Itoa(m.thresholds[kind]))
+	}
+
+	return strings.Join(specs, ",")
+}
+
+// Set parses a comma separated list of kind=count pairs and adds them to the
+// thresholds, overriding earlier values for the same kind.
+func (m *minBlockStmts) Set(value string) error {
+	thresholds := make(map[string]int)
+	for spec := range strings.SplitSeq(value, ",") {
+		kind, count, ok := strings.Cut(strings.TrimSpace(spec), "=")
+		if !ok {
+			return fmt.Errorf("invalid block statement count %q: expected kind=count", spec)
+		}
+
+		if !slices.Contains(blockKinds, kind) {
+			return fmt.Errorf("invalid block kind %q: expected one of %s", kind, strings.Join(blockKinds, ", "))
+		}
+
+		minimum, err := strconv.Atoi(count)
+		if err != nil || minimum < 0 {
+			return fmt.Errorf("invalid block statement count %q for kind %q: expected a non-negative integer", count, kind)
+		}
+
+		thresholds[kind] = minimum
+	}
+
+	if m.thresholds == nil {
+		m.thresholds = make(map[string]int, len(thresholds))
+	}
+
+	maps.Copy(m.thresholds, thresholds)
+	return nil
+}
+
+// satisfiedBy checks if a block of the given kind with count body statements
+// reaches the configured minimum. Kinds without a threshold always satisfy it.
+func (m *minBlockStmts) satisfiedBy(kind string, count int) bool {
+	minimum, ok := m.thresholds[kind]
+	return !ok || count >= minimum
+}
@@ -2,11 +2,17 @@
 package newlineafterblock
 
 import (
+	"bytes"
+	"cmp"
+	"fmt"
 	"go/ast"
 	"go/token"
 	"go/types"
+	"math"
 	"os"
 	"path/filepath"
+	"slices"
+	"strings"
 
 	"golang.org/x/tools/go/analysis"
 )
@@ -31,8 +37,8 @@ followed by a blank line to improve readability.
 
 Special handling for defer statements:
 - Defer statements can immediately follow error-checking if statements (if <error> != nil)
-  without a blank line (idiomatic Go pattern for cleanup)
-- Error detection is type-based: any variable implementing the error interface is recognized
+  without a blank line (idiomatic Go pattern for cleanup), unless a comment sits in between
+- Error detection is type-based: any variable or struct field implementing the error interface is recognized
 - Multiple consecutive defer statements do not require blank lines between them
 - A blank line is required after defer statement(s) before any non-defer statement
 
@@ -43,12 +49,66 @@ The analyzer provides automatic fix suggestions that insert the required blank
 lines.`
 
 type newlineafterblock struct {
-	exclude excludePatterns
+	exclude             excludePatterns
+	compact             excludePatterns
+	blockKinds          blockKindSet
+	minBlockStmts       minBlockStmts
+	maxBlankLines       int
+	deferAnyGuard       bool
+	funcLitArgs         bool
+	lastCaseBlock       bool
+	blankAfterGoto      bool
+	deferGroupByComment bool
+	caseConsistency     bool
+	reportOncePerBlock  bool
+	noExclude           bool
+	goDeferAdjacent     bool
+	namedFuncLitOnly    bool
+	typeDecls           bool
+	sameLineStatement   bool
+	checkCaseComments   bool
+	caseClauses         bool
+	relaxMainInit       bool
+	exportedOnly        bool
+	multilineOnly       bool
+	requireBefore       bool
+	message             messageTemplate
+	reportAtNext        bool
+	strictDefer         bool
+	strictGuardSpacing  bool
+	bareBlocks          bool
+	selectClauseBodies  bool
+
+	// compactFile is only set on the copies used for compact files and, with
+	// -relax-main-init, for main and init functions.
+	compactFile bool
+
+	// configErr holds the error of an invalid configuration passed to NewWithConfig.
+	configErr error
+
+	// nolint and sources are only set on the copy used for a single run, they
+	// hold the lines suppressed by //nolint directives and the file contents.
+	nolint  nolintLines
+	sources *sourceFiles
 }
 
+// Analyzer is a package level newline-after-block analyzer instance, e.g. for
+// plugin registries and multi-analyzer drivers. Its flags are shared by all
+// users, use New to create independent instances.
+var Analyzer = New()
+
 // New creates and returns a new newline-after-block analyzer instance.
 func New() *analysis.Analyzer {
+	return NewWithConfig(DefaultConfig())
+}
+
+// NewWithConfig creates and returns a new newline-after-block analyzer instance
+// configured by cfg. The flags are registered as well, the values of cfg are
+// their defaults. An invalid configuration is reported as error by the run of
+// the analyzer.
+func NewWithConfig(cfg Config) *analysis.Analyzer {
 	nlab := newlineafterblock{}
+	nlab.configErr = nlab.applyConfig(cfg)
 
 	analyzer := &analysis.Analyzer{
 		Name: "newlineafterblock",
@@ -59,91 +119,481 @@ func New() *analysis.Analyzer {
 	// Register flags on this analyzer instance.
 	analyzer.Flags.Var(&nlab.exclude, "exclude", "regex pattern to exclude files from analysis")
 	analyzer.Flags.Var(&nlab.exclude, "e", "regex pattern to exclude files from analysis (shorthand)")
+	analyzer.Flags.BoolVar(&nlab.noExclude, "no-exclude", cfg.NoExclude,
+		"ignore all exclude patterns and analyze every file, e.g. to audit what is being skipped")
+	analyzer.Flags.Var(&nlab.compact, "compact-files",
+		"regex pattern for files in which missing blank lines after blocks are not reported")
+	analyzer.Flags.IntVar(&nlab.maxBlankLines, "max-blank-lines", cfg.maxBlankLines(),
+		"also report more than the given number of blank lines after block statements, fixes remove the surplus blank lines (0 disables the check)")
+	analyzer.Flags.Var(normalizeFlag{maxBlankLines: &nlab.maxBlankLines}, "normalize",
+		"alias of -max-blank-lines=1, also report more than one blank line after block statements, fixes normalize the gap to exactly one blank line")
+	analyzer.Flags.BoolVar(&nlab.deferAnyGuard, "defer-exception-any-guard", cfg.DeferExceptionAnyGuard,
+		"allow defer immediately after any guard if statement (single terminating statement), not only error checks")
+	analyzer.Flags.BoolVar(&nlab.funcLitArgs, "check-trailing-funclit-args", cfg.CheckTrailingFuncLitArgs,
+		"require a blank line after call statements whose last argument is a multi-line function literal")
+	analyzer.Flags.BoolVar(&nlab.bareBlocks, "bare-blocks", cfg.BareBlocks,
+		"require a blank line after bare blocks used for scoping, e.g. { x := 1; use(x) }")
+	analyzer.Flags.BoolVar(&nlab.selectClauseBodies, "select-clause-bodies", cfg.SelectClauseBodies,
+		"also check the statements inside the case clauses of select statements, like those of switch statements")
+	analyzer.Flags.BoolVar(&nlab.lastCaseBlock, "last-case-block", cfg.LastCaseBlock,
+		"require a blank line between a block ending the last case of a switch and the closing brace")
+	analyzer.Flags.BoolVar(&nlab.blankAfterGoto, "blank-after-goto", cfg.BlankAfterGoto,
+		"require a blank line after goto statements before any non-goto statement")
+	analyzer.Flags.BoolVar(&nlab.deferGroupByComment, "defer-group-by-comment", cfg.DeferGroupByComment,
+		"treat a comment between consecutive defer statements as the start of a new group, which requires a blank line before it")
+	analyzer.Flags.BoolVar(&nlab.strictDefer, "strict-defer", cfg.StrictDefer,
+		"require a blank line before and between defer statements, disabling the error check and consecutive defer exceptions")
+	analyzer.Flags.BoolVar(&nlab.strictGuardSpacing, "strict-guard-spacing", cfg.StrictGuardSpacing,
+		"always require a blank line after guard if statements (single terminating statement), also before defer and regardless of -block-kinds, -min-block-stmts and -multiline-blocks-only")
+	analyzer.Flags.BoolVar(&nlab.goDeferAdjacent, "allow-go-defer-adjacent", cfg.AllowGoDeferAdjacent,
+		"allow go and defer statements immediately after each other, e.g. go producer() followed by defer close(ch)")
+	analyzer.Flags.BoolVar(&nlab.namedFuncLitOnly, "named-funclit-only", cfg.NamedFuncLitOnly,
+		"only require a blank line after function literals assigned to a named variable, not to the blank identifier, an index or a field")
+	analyzer.Flags.BoolVar(&nlab.typeDecls, "flag-type-decls", cfg.FlagTypeDecls,
+		"require a blank line after type declarations spanning multiple lines inside functions, e.g. of interfaces or structs")
+	analyzer.Flags.BoolVar(&nlab.sameLineStatement, "flag-same-line-statement", cfg.FlagSameLineStatement,
+		"report statements on the same line as the end of a block statement, e.g. }; foo()")
+	analyzer.Flags.BoolVar(&nlab.caseClauses, "case-clauses", !cfg.DisableCaseClauses,
+		"require a blank line between case blocks of switch and select statements, if false only the after-block rule applies")
+	analyzer.Flags.BoolVar(&nlab.checkCaseComments, "check-case-comments", !cfg.AllowCaseComments,
+		"require a blank line between a case body and a comment before the next case, if false the comment may directly follow the case body")
+	analyzer.Flags.BoolVar(&nlab.caseConsistency, "case-consistency", cfg.CaseConsistency,
+		"only report missing blank lines between case blocks if other case blocks of the same switch or select are separated")
+	analyzer.Flags.BoolVar(&nlab.exportedOnly, "exported-only", cfg.ExportedOnly,
+		"only analyze exported functions and exported methods of exported types")
+	analyzer.Flags.BoolVar(&nlab.relaxMainInit, "relax-main-init", cfg.RelaxMainInit,
+		"do not report missing blank lines inside func main and func init, like in compact files")
+	analyzer.Flags.BoolVar(&nlab.multilineOnly, "multiline-blocks-only", cfg.MultilineBlocksOnly,
+		"only require a blank line after blocks spanning multiple lines, not after one-line blocks like if x { y() }")
+	analyzer.Flags.BoolVar(&nlab.requireBefore, "require-before", cfg.RequireBefore,
+		"also require a blank line before block statements directly following a non-block statement")
+	analyzer.Flags.Var(&nlab.message, "message",
+		"template for the messages of missing blank lines, e.g. \"missing newline after {{.Kind}} block\" (kinds: if, for, range, switch, select, func, type, defer, go, goto, call, block, case)")
+	analyzer.Flags.BoolVar(&nlab.reportAtNext, "report-at-next", cfg.ReportAtNext,
+		"report missing blank lines at the start of the following line instead of the end of the block")
+	analyzer.Flags.BoolVar(&nlab.reportOncePerBlock, "report-once-per-block", cfg.ReportOncePerBlock,
+		"report at most one diagnostic per block end, if several checks report at the same position only the first is kept")
+	analyzer.Flags.Var(&nlab.blockKinds, "block-kinds",
+		"comma separated list of block kinds the after-block rule applies to (if, for, range, switch, select, func), default all")
+	analyzer.Flags.Var(&nlab.minBlockStmts, "min-block-stmts",
+		"per kind minimum number of body statements for a block to require a blank line after it, e.g. if=2,for=1")
 
 	return analyzer
 }
 
 func (n *newlineafterblock) run(pass *analysis.Pass) (any, error) {
+	if n.configErr != nil {
+		return nil, fmt.Errorf("invalid configuration: %w", n.configErr)
+	}
+
 	wd, err := os.Getwd()
 	if err != nil {
 		wd = ""
 	}
 
+	runner := *n
+	runner.nolint = collectNolintLines(pass)
+	runner.sources = newSourceFiles(pass)
+
+	pass = withNolint(pass, runner.nolint)
+	pass = withDisabledRegions(pass)
+
+	if n.reportOncePerBlock {
+		pass = reportOncePerPos(pass)
+	}
+
 	for _, file := range pass.Files {
-		if n.shouldSkipFile(pass, file, wd) {
+		if runner.shouldSkipFile(pass, file, wd) {
 			continue
 		}
 
-		ast.Inspect(file, func(node ast.Node) bool {
-			n.inspectNode(pass, file, node)
-			return true
-		})
+		checker := &runner
+		if runner.isCompactFile(pass, file, wd) {
+			compact := runner
+			compact.compactFile = true
+			checker = &compact
+		}
+
+		checker.inspect(pass, file, file)
 	}
 
 	return nil, nil
 }
 
+// inspect inspects all nodes below root. With -relax-main-init, main and init
+// functions are inspected with the relaxed rules of compact files. With
+// -exported-only, only exported functions are inspected.
+func (n *newlineafterblock) inspect(pass *analysis.Pass, file *ast.File, root ast.Node) {
+	ast.Inspect(root, func(node ast.Node) bool {
+		if _, ok := node.(*ast.File); ok && n.exportedOnly {
+			for _, decl := range file.Decls {
+				if funcDecl, ok := decl.(*ast.FuncDecl); ok && isExportedFunc(funcDecl) {
+					n.inspect(pass, file, funcDecl)
+				}
+			}
+
+			return false
+		}
+
+		funcDecl, ok := node.(*ast.FuncDecl)
+		if ok && n.relaxMainInit && !n.compactFile && isMainOrInit(file, funcDecl) {
+			relaxed := *n
+			relaxed.compactFile = true
+			relaxed.inspect(pass, file, funcDecl)
+
+			return false
+		}
+
+		n.inspectNode(pass, file, node)
+		return true
+	})
+}
+
+// isMainOrInit checks if a function declaration is an init function or the
+// main function of package main.
+func isMainOrInit(file *ast.File, funcDecl *ast.FuncDecl) bool {
+	if funcDecl.Recv != nil {
+		return false
+	}
+
+	return funcDecl.Name.Name == "init" || (funcDecl.Name.Name == "main" && file.Name.Name == "main")
+}
+
+// isExportedFunc checks if a function declaration is exported. Methods are
+// only exported if their receiver type is exported as well.
+func isExportedFunc(funcDecl *ast.FuncDecl) bool {
+	if !funcDecl.Name.IsExported() {
+		return false
+	}
+
+	if funcDecl.Recv == nil || len(funcDecl.Recv.List) == 0 {
+		return true
+	}
+
+	recvType := funcDecl.Recv.List[0].Type
+	for {
+		switch t := recvType.(type) {
+		case *ast.StarExpr:
+			recvType = t.X
+
+		case *ast.IndexExpr:
+			recvType = t.X
+
+		case *ast.IndexListExpr:
+			recvType = t.X
+
+		case *ast.ParenExpr:
+			recvType = t.X
+
+		case *ast.Ident:
+			return t.IsExported()
+
+		default:
+			return false
+		}
+	}
+}
+
+// reportOncePerPos returns a copy of the pass that drops diagnostics reported
+// at a position that already has a diagnostic, the first one including its
+// suggested fix is kept.
+func reportOncePerPos(pass *analysis.Pass) *analysis.Pass {
+	reported := make(map[token.Pos]bool)
+
+	once := *pass
+	once.Report = func(diagnostic analysis.Diagnostic) {
+		if reported[diagnostic.Pos] {
+			return
+		}
+
+		reported[diagnostic.Pos] = true
+		pass.Report(diagnostic)
+	}
+
+	return &once
+}
+
+// nolintLines holds the lines with a //nolint or //nolint:newlineafterblock
+// directive per file name.
+type nolintLines map[string]map[int]bool
+
+// collectNolintLines collects the lines with a //nolint or
+// //nolint:newlineafterblock directive of all files of the pass.
+func collectNolintLines(pass *analysis.Pass) nolintLines {
+	suppressed := make(nolintLines)
+
+	for _, file := range pass.Files {
+		for _, commentGroup := range file.Comments {
+			for _, comment := range commentGroup.List {
+				if !isNolintDirective(comment.Text) {
+					continue
+				}
+
+				position := pass.Fset.Position(comment.Pos())
+				if suppressed[position.Filename] == nil {
+					suppressed[position.Filename] = make(map[int]bool)
+				}
+
+				suppressed[position.Filename][position.Line] = true
+			}
+		}
+	}
+
+	return suppressed
+}
+
+// suppresses checks if a diagnostic at position is suppressed by a directive.
+func (l nolintLines) suppresses(position token.Position) bool {
+	return l[position.Filename][position.Line]
+}
+
+// withNolint returns a copy of the pass that drops diagnostics reported on a
+// line in suppressed. The pass is returned unchanged if there are no such
+// lines.
+func withNolint(pass *analysis.Pass, suppressed nolintLines) *analysis.Pass {
+	if len(suppressed) == 0 {
+		return pass
+	}
+
+	nolint := *pass
+	nolint.Report = func(diagnostic analysis.Diagnostic) {
+		if suppressed.suppresses(pass.Fset.Position(diagnostic.Pos)) {
+			return
+		}
+
+		pass.Report(diagnostic)
+	}
+
+	return &nolint
+}
+
+// lineRange is a range of lines, including the first and the last line.
+type lineRange struct {
+	first, last int
+}
+
+// withDisabledRegions returns a copy of the pass that drops diagnostics
+// reported in a region between a //newlineafterblock:disable and a
+// //newlineafterblock:enable directive. A region without enable directive
+// ends at the end of the file. The pass is returned unchanged if there are no
+// such regions.
+func withDisabledRegions(pass *analysis.Pass) *analysis.Pass {
+	disabled := make(map[string][]lineRange)
+
+	for _, file := range pass.Files {
+		first := 0
+		for _, commentGroup := range file.Comments {
+			for _, comment := range commentGroup.List {
+				line := pass.Fset.Position(comment.Pos()).Line
+
+				switch {
+				case isDirective(comment.Text, "//newlineafterblock:disable") && first == 0:
+					first = line
+
+				case isDirective(comment.Text, "//newlineafterblock:enable") && first != 0:
+					filename := pass.Fset.Position(comment.Pos()).Filename
+					disabled[filename] = append(disabled[filename], lineRange{first: first, last: line})
+					first = 0
+				}
+			}
+		}
+
+		if first != 0 {
+			filename := pass.Fset.Position(file.Package).Filename
+			disabled[filename] = append(disabled[filename], lineRange{first: first, last: math.MaxInt})
+		}
+	}
+
+	if len(disabled) == 0 {
+		return pass
+	}
+
+	regions := *pass
+	regions.Report = func(diagnostic analysis.Diagnostic) {
+		position := pass.Fset.Position(diagnostic.Pos)
+		inRegion := func(r lineRange) bool { return r.first <= position.Line && position.Line <= r.last }
+
+		if slices.ContainsFunc(disabled[position.Filename], inRegion) {
+			return
+		}
+
+		pass.Report(diagnostic)
+	}
+
+	return &regions
+}
+
+// isDirective checks if a comment is the given directive, optionally followed
+// by an explanation after a space.
+func isDirective(text, directive string) bool {
+	rest, ok := strings.CutPrefix(text, directive)
+	return ok && (rest == "" || rest[0] == ' ' || rest[0] == '\t')
+}
+
+// isNolintDirective checks if a comment is a bare //nolint directive or a
+// //nolint directive listing this linter, e.g. //nolint:newlineafterblock,lll.
+// Like golangci-lint, an explanation may follow after a space.
+func isNolintDirective(text string) bool {
+	if isDirective(text, "//nolint") {
+		return true
+	}
+
+	linters, ok := strings.CutPrefix(text, "//nolint:")
+	if !ok {
+		return false
+	}
+
+	linters, _, _ = strings.Cut(linters, " ")
+	for linter := range strings.SplitSeq(linters, ",") {
+		if linter == "newlineafterblock" || linter == "newline-after-block" {
+			return true
+		}
+	}
+
+	return false
+}
+
 // shouldSkipFile determines if a file should be skipped based on exclude patterns.
 func (n *newlineafterblock) shouldSkipFile(pass *analysis.Pass, file *ast.File, wd string) bool {
+	if n.noExclude {
+		return false
+	}
+
+	return n.exclude.matches(relativePath(pass, file, wd))
+}
+
+// isCompactFile determines if a file matches the compact file patterns.
+func (n *newlineafterblock) isCompactFile(pass *analysis.Pass, file *ast.File, wd string) bool {
+	return n.compact.matches(relativePath(pass, file, wd))
+}
+
+// relativePath returns the path of a file relative to the working directory.
+func relativePath(pass *analysis.Pass, file *ast.File, wd string) string {
 	relPath, err := filepath.Rel(wd, pass.Fset.Position(file.Package).Filename)
 	if err != nil {
 		relPath = pass.Fset.Position(file.Package).Filename
 	}
 
-	return n.exclude.matches(relPath)
+	return relPath
 }
 
 // inspectNode inspects an AST node and performs appropriate checks.
 func (n *newlineafterblock) inspectNode(pass *analysis.Pass, file *ast.File, node ast.Node) {
-	switch n := node.(type) {
+	switch stmt := node.(type) {
 	case *ast.BlockStmt:
-		checkStatements(pass, file, n.List)
-
-	case *ast.CaseClause:
-		checkStatements(pass, file, n.Body)
+		n.checkStatements(pass, file, stmt.List, stmt.Rbrace)
+		n.checkCaseClauseBodies(pass, file, stmt)
 
 	case *ast.SwitchStmt:
-		if n.Body != nil {
-			checkCaseClauses(pass, file, n.Body.List)
+		if stmt.Body != nil {
+			n.checkCaseClauses(pass, file, stmt.Body)
 		}
 
 	case *ast.TypeSwitchStmt:
-		if n.Body != nil {
-			checkCaseClauses(pass, file, n.Body.List)
+		if stmt.Body != nil {
+			n.checkCaseClauses(pass, file, stmt.Body)
 		}
 
 	case *ast.SelectStmt:
-		if n.Body != nil {
-			checkCommClauses(pass, file, n.Body.List)
+		if stmt.Body != nil {
+			n.checkCommClauses(pass, file, stmt.Body.List)
+		}
+	}
+}
+
+// checkCaseClauseBodies checks the statements of the case clauses in a switch
+// body, with -select-clause-bodies also in a select body. Trailing comments of
+// a clause are only considered up to the start of the next clause, for the
+// last clause up to the closing brace.
+func (n *newlineafterblock) checkCaseClauseBodies(pass *analysis.Pass, astFile *ast.File, body *ast.BlockStmt) {
+	for i, stmt := range body.List {
+		var clauseBody []ast.Stmt
+
+		switch clause := stmt.(type) {
+		case *ast.CaseClause:
+			clauseBody = clause.Body
+
+		case *ast.CommClause:
+			if !n.selectClauseBodies {
+				continue
+			}
+
+			clauseBody = clause.Body
+
+		default:
+			continue
+		}
+
+		end := body.Rbrace
+		if i+1 < len(body.List) {
+			end = body.List[i+1].Pos()
 		}
+
+		n.checkStatements(pass, astFile, clauseBody, end)
 	}
 }
 
 // checkStatements checks a sequence of statements for missing newlines after blocks.
-func checkStatements(pass *analysis.Pass, astFile *ast.File, stmts []ast.Stmt) {
+// The end position marks the end of the enclosing block, comments after it are not
+// considered to follow the last statement.
+func (n *newlineafterblock) checkStatements(pass *analysis.Pass, astFile *ast.File, stmts []ast.Stmt, end token.Pos) {
+	stmts, emptyLines := withoutEmptyStmts(pass.Fset, stmts)
+
 	for i := 0; i < len(stmts)-1; i++ {
-		checkStatementPair(pass, astFile, stmts[i], stmts[i+1])
+		n.checkStatementPair(pass, astFile, stmts[i], stmts[i+1], emptyLines)
+
+		if n.requireBefore && !n.compactFile {
+			n.checkBlockBefore(pass, astFile, stmts[i], stmts[i+1])
+		}
 	}
 
 	// Also check the last statement if it's followed by a comment.
-	if len(stmts) > 0 {
-		checkLastStatement(pass, astFile, stmts[len(stmts)-1])
+	if len(stmts) > 0 && !n.compactFile {
+		n.checkLastStatement(pass, astFile, stmts[len(stmts)-1], end)
 	}
 }
 
-// checkStatementPair checks if there's proper spacing between two consecutive statements.
-func checkStatementPair(pass *analysis.Pass, astFile *ast.File, current, next ast.Stmt) {
-	// Exception: Allow defer immediately after error-checking if statement.
-	if isErrorCheckIfStmt(pass, current) && isDeferStmt(next) {
-		return
+// withoutEmptyStmts removes empty statements (stray semicolons) from a statement list.
+// gofmt drops them as well, so blocks are compared with the next real statement.
+// The lines of the removed empty statements are returned as well.
+func withoutEmptyStmts(fset *token.FileSet, stmts []ast.Stmt) ([]ast.Stmt, map[int]bool) {
+	filtered := make([]ast.Stmt, 0, len(stmts))
+	emptyLines := make(map[int]bool)
+
+	for _, stmt := range stmts {
+		if _, ok := stmt.(*ast.EmptyStmt); ok {
+			emptyLines[fset.Position(stmt.Pos()).Line] = true
+			continue
+		}
+
+		filtered = append(filtered, stmt)
 	}
 
-	// Exception: Allow consecutive defer statements without blank line.
-	if isDeferStmt(current) && isDeferStmt(next) {
+	return filtered, emptyLines
+}
+
+// onlyEmptyStmtLines checks if all lines between first and last, both
+// exclusive, hold an empty statement. gofmt removes these lines, so they do
+// not count as blank lines.
+func onlyEmptyStmtLines(first, last int, emptyLines map[int]bool) bool {
+	for line := first + 1; line < last; line++ {
+		if !emptyLines[line] {
+			return false
+		}
+	}
+
+	return true
+}
+
+// checkStatementPair checks if there's proper spacing between two consecutive statements.
+// Lines holding only empty statements between them are not considered blank.
+func (n *newlineafterblock) checkStatementPair(pass *analysis.Pass, astFile *ast.File, current, next ast.Stmt, emptyLines map[int]bool) {
+	// Skip regions that could not be parsed (files with syntax errors).
+	if isBadStmt(current) || isBadStmt(next) {
 		return
 	}
 
-	if !needsNewlineAfter(current) {
+	if !n.needsNewlineAfter(pass, current) {
 		return
 	}
 
@@ -160,22 +610,169 @@ func checkStatementPair(pass *analysis.Pass, astFile *ast.File, current, next as
 	blockEndLine := file.Line(blockEnd)
 	nextLine := file.Line(next.Pos())
 
+	if n.maxBlankLines > 0 {
+		message := n.message.formatDetailed(messageKind(current), "too many blank lines after block statement")
+		n.checkExcessBlankLines(pass, astFile, file, blockEnd, blockEndLine, next.Pos(), message)
+	}
+
+	if n.allowsAdjacent(pass, astFile, current, next) {
+		return
+	}
+
+	// Compact files relax the missing blank line rule.
+	if n.compactFile {
+		return
+	}
+
+	// Statements on the same line as the closing brace are only possible with
+	// an explicit semicolon, which gofmt splits into separate lines.
+	if n.sameLineStatement && nextLine == blockEndLine {
+		indent := strings.Repeat("\t", pass.Fset.Position(current.Pos()).Column-1)
+		message := n.message.formatDetailed(messageKind(current), "statement on the same line as the end of block statement")
+		pass.Report(createDiagnosticWithSplitFix(file, n.sources.get(file), blockEnd, next.Pos(), indent, message))
+		return
+	}
+
 	// Check if there's a comment between the block and the next statement.
-	foundComment := checkCommentBetween(pass, astFile, file, blockEnd, blockEndLine, next.Pos())
+	message := n.message.format(messageKind(current), "missing newline after block statement")
+	foundComment := n.checkCommentBetween(pass, astFile, file, blockEnd, blockEndLine, next.Pos(), message)
 
 	// If no comment was found between the block and next statement,
 	// check if the next statement is immediately after (no blank line).
-	if !foundComment && nextLine == blockEndLine+1 {
-		pass.Report(createDiagnosticWithFix(pass, blockEnd, "missing newline after block statement"))
+	if !foundComment && nextLine > blockEndLine && onlyEmptyStmtLines(blockEndLine, nextLine, emptyLines) {
+		n.reportMissingNewline(pass, blockEnd, message)
+	}
+}
+
+// allowsAdjacent checks if next may immediately follow current due to one of
+// the exceptions of the missing blank line rule. The exceptions do not apply to
+// surplus blank lines.
+func (n *newlineafterblock) allowsAdjacent(pass *analysis.Pass, astFile *ast.File, current, next ast.Stmt) bool {
+	// Exception: Allow defer immediately after error-checking if statement.
+	// A comment in between breaks the exception and the block to comment rule applies.
+	if !n.strictDefer && n.allowsDeferAfter(pass, current) && isDeferStmt(next) &&
+		!hasCommentBetween(pass, astFile, current, next) {
+		return true
+	}
+
+	// Exception: Allow consecutive defer statements without blank line.
+	// If enabled, a comment in between starts a new group of defers.
+	if !n.strictDefer && isDeferStmt(current) && isDeferStmt(next) &&
+		(!n.deferGroupByComment || !hasCommentBetween(pass, astFile, current, next)) {
+		return true
+	}
+
+	// Exception: Allow adjacent go and defer statements without blank line if enabled.
+	if n.goDeferAdjacent && isGoDeferPair(current, next) {
+		return true
 	}
+
+	// Exception: Allow consecutive goto statements without blank line.
+	if isGotoStmt(current) && isGotoStmt(next) {
+		return true
+	}
+
+	return false
+}
+
+// checkBlockBefore checks if there's a blank line between a non-block statement
+// and a directly following block statement. A comment on its own line between
+// them counts as separator, like a blank line.
+func (n *newlineafterblock) checkBlockBefore(pass *analysis.Pass, astFile *ast.File, current, next ast.Stmt) {
+	if isBadStmt(current) || isBadStmt(next) {
+		return
+	}
+
+	// Blocks following a block are covered by the after-block rule.
+	if n.needsNewlineAfter(pass, current) || !n.isBlockStmt(pass, next) {
+		return
+	}
+
+	file := pass.Fset.File(current.End())
+	if file == nil {
+		return
+	}
+
+	currentEndLine := file.Line(current.End())
+
+	for _, commentGroup := range commentsFrom(astFile, current.End()) {
+		if commentGroup.Pos() >= next.Pos() {
+			break
+		}
+
+		// Skip inline comments (on the same line as the end of current).
+		if file.Line(commentGroup.Pos()) != currentEndLine {
+			return
+		}
+	}
+
+	if file.Line(next.Pos()) == currentEndLine+1 {
+		message := n.message.formatDetailed(messageKind(next), "missing newline before block statement")
+		pass.Report(createDiagnosticWithBeforeFix(file, n.sources.get(file), current.End(), next.Pos(), message))
+	}
+}
+
+// isBlockStmt checks if a statement is a block statement for the -require-before
+// flag. Defer and goto statements do not start a block.
+func (n *newlineafterblock) isBlockStmt(pass *analysis.Pass, stmt ast.Stmt) bool {
+	switch unlabel(stmt).(type) {
+	case *ast.DeferStmt, *ast.BranchStmt:
+		return false
+	}
+
+	return n.needsNewlineAfter(pass, stmt)
+}
+
+// checkExcessBlankLines checks for more than the allowed number of blank lines between a
+// block end and the first following comment or statement (-max-blank-lines, -normalize).
+func (n *newlineafterblock) checkExcessBlankLines(pass *analysis.Pass, astFile *ast.File, file *token.File, blockEnd token.Pos, blockEndLine int, nextPos token.Pos, message string) {
+	lastLine := blockEndLine
+	followLine := file.Line(nextPos)
+
+	for _, commentGroup := range commentsFrom(astFile, blockEnd) {
+		if commentGroup.Pos() >= nextPos {
+			break
+		}
+
+		commentLine := file.Line(commentGroup.Pos())
+		// Inline comments may span several lines, the gap starts after them.
+		if commentLine == blockEndLine {
+			lastLine = max(lastLine, file.Line(commentGroup.End()))
+			continue
+		}
+
+		followLine = commentLine
+
+		break
+	}
+
+	if followLine-lastLine-1 <= n.maxBlankLines {
+		return
+	}
+
+	// Keep the allowed blank lines and remove all the others.
+	pass.Report(createDiagnosticWithRemovalFix(file, n.sources.get(file), blockEnd, file.LineStart(lastLine+1+n.maxBlankLines), file.LineStart(followLine), message))
+}
+
+// commentsFrom returns the comment groups of a file starting at or after pos.
+// The comment groups of a file are sorted by position, which allows to find
+// the first one by binary search instead of scanning all comments.
+func commentsFrom(astFile *ast.File, pos token.Pos) []*ast.CommentGroup {
+	i, _ := slices.BinarySearchFunc(astFile.Comments, pos, func(commentGroup *ast.CommentGroup, pos token.Pos) int {
+		return cmp.Compare(commentGroup.Pos(), pos)
+	})
+
+	return astFile.Comments[i:]
 }
 
 // checkCommentBetween checks for comments between a block end and the next statement.
 // Returns true if a non-inline comment was found.
-func checkCommentBetween(pass *analysis.Pass, astFile *ast.File, file *token.File, blockEnd token.Pos, blockEndLine int, nextPos token.Pos) bool {
-	for _, commentGroup := range astFile.Comments {
-		if commentGroup.Pos() <= blockEnd || commentGroup.Pos() >= nextPos {
-			continue
+func (n *newlineafterblock) checkCommentBetween(pass *analysis.Pass, astFile *ast.File, file *token.File, blockEnd token.Pos, blockEndLine int, nextPos token.Pos, message string) bool {
+	// blockEnd is right after the closing brace, a comment glued to the
+	// brace starts exactly there and belongs to the gap.
+	for _, commentGroup := range commentsFrom(astFile, blockEnd) {
+		if commentGroup.Pos() >= nextPos {
+			break
 		}
 
 		commentLine := file.Line(commentGroup.Pos())
@@ -187,7 +784,7 @@ func checkCommentBetween(pass *analysis.Pass, astFile *ast.File, file *token.Fil
 		// Found a comment on a different line.
 		// If comment is on the next line (no blank line).
 		if commentLine == blockEndLine+1 {
-			pass.Report(createDiagnosticWithFix(pass, blockEnd, "missing newline after block statement"))
+			n.reportMissingNewline(pass, blockEnd, message)
 		}
 
 		// Only check the first non-inline comment.
@@ -197,9 +794,32 @@ func checkCommentBetween(pass *analysis.Pass, astFile *ast.File, file *token.Fil
 	return false
 }
 
+// hasCommentBetween checks if there is a non-inline comment between two statements.
+func hasCommentBetween(pass *analysis.Pass, astFile *ast.File, current, next ast.Stmt) bool {
+	file := pass.Fset.File(current.End())
+	if file == nil {
+		return false
+	}
+
+	currentEndLine := file.Line(current.End())
+
+	for _, commentGroup := range commentsFrom(astFile, current.End()) {
+		if commentGroup.Pos() >= next.Pos() {
+			break
+		}
+
+		// Inline comments (on the same line as the end of current) do not count.
+		if file.Line(commentGroup.Pos()) != currentEndLine {
+			return true
+		}
+	}
+
+	return false
+}
+
 // checkLastStatement checks if the last statement has proper spacing before any trailing comments.
-func checkLastStatement(pass *analysis.Pass, astFile *ast.File, lastStmt ast.Stmt) {
-	if !needsNewlineAfter(lastStmt) {
+func (n *newlineafterblock) checkLastStatement(pass *analysis.Pass, astFile *ast.File, lastStmt ast.Stmt, end token.Pos) {
+	if !n.needsNewlineAfter(pass, lastStmt) {
 		return
 	}
 
@@ -216,14 +836,16 @@ func checkLastStatement(pass *analysis.Pass, astFile *ast.File, lastStmt ast.Stm
 	blockEndLine := file.Line(blockEnd)
 
 	// Check if there's a comment after the last statement.
-	checkTrailingComment(pass, astFile, file, blockEnd, blockEndLine)
+	message := n.message.format(messageKind(lastStmt), "missing newline after block statement")
+	n.checkTrailingComment(pass, astFile, file, blockEnd, blockEndLine, end, message)
 }
 
 // checkTrailingComment checks for comments after a block statement.
-func checkTrailingComment(pass *analysis.Pass, astFile *ast.File, file *token.File, blockEnd token.Pos, blockEndLine int) {
-	for _, commentGroup := range astFile.Comments {
-		if commentGroup.Pos() <= blockEnd {
-			continue
+// Comments after the end of the enclosing block are not considered.
+func (n *newlineafterblock) checkTrailingComment(pass *analysis.Pass, astFile *ast.File, file *token.File, blockEnd token.Pos, blockEndLine int, end token.Pos, message string) {
+	for _, commentGroup := range commentsFrom(astFile, blockEnd) {
+		if end.IsValid() && commentGroup.Pos() >= end {
+			break
 		}
 
 		commentLine := file.Line(commentGroup.Pos())
@@ -234,7 +856,7 @@ func checkTrailingComment(pass *analysis.Pass, astFile *ast.File, file *token.Fi
 
 		// If comment is on the next line (no blank line).
 		if commentLine == blockEndLine+1 {
-			pass.Report(createDiagnosticWithFix(pass, blockEnd, "missing newline after block statement"))
+			n.reportMissingNewline(pass, blockEnd, message)
 		}
 
 		// Only check the first comment after the block.
@@ -244,16 +866,31 @@ func checkTrailingComment(pass *analysis.Pass, astFile *ast.File, file *token.Fi
 
 // checkCaseClauses checks that case clauses in switch/select statements are properly spaced.
 // Each case clause (except the last) should be followed by a blank line.
-func checkCaseClauses(pass *analysis.Pass, astFile *ast.File, stmts []ast.Stmt) {
-	caseClauses := extractCaseClauses(stmts)
-	if len(caseClauses) < 2 {
+func (n *newlineafterblock) checkCaseClauses(pass *analysis.Pass, astFile *ast.File, body *ast.BlockStmt) {
+	// Compact files relax the missing blank line rule, case clause spacing included.
+	if n.compactFile {
+		return
+	}
+
+	caseClauses := extractCaseClauses(body.List)
+
+	if n.lastCaseBlock && len(caseClauses) > 0 {
+		n.checkLastCaseBlock(pass, caseClauses[len(caseClauses)-1], body.Rbrace)
+	}
+
+	if !n.caseClauses || len(caseClauses) < 2 {
 		return
 	}
 
 	// Check spacing between consecutive case clauses.
+	var gaps []clauseGap
 	for i := 0; i < len(caseClauses)-1; i++ {
-		checkCaseClauseSpacing(pass, astFile, caseClauses[i], caseClauses[i+1])
+		if gap, ok := n.findClauseGap(pass, astFile, caseClauses[i].Body, caseClauses[i+1].Pos()); ok {
+			gaps = append(gaps, gap)
+		}
 	}
+
+	n.reportClauseGaps(pass, gaps)
 }
 
 // extractCaseClauses filters statements to only CaseClause nodes.
@@ -268,40 +905,107 @@ func extractCaseClauses(stmts []ast.Stmt) []*ast.CaseClause {
 	return caseClauses
 }
 
-// checkCaseClauseSpacing checks spacing between two consecutive case clauses.
-func checkCaseClauseSpacing(pass *analysis.Pass, astFile *ast.File, current, next *ast.CaseClause) {
-	// Skip empty case clauses (no body statements).
-	if len(current.Body) == 0 {
-		return
+// clauseGap describes the spacing between the body of a clause and the next clause.
+type clauseGap struct {
+	// end is the end of the last statement in the clause body.
+	end token.Pos
+	// missing is set if there is no blank line after the clause body.
+	missing bool
+}
+
+// findClauseGap determines the spacing between a clause body and the next clause,
+// a comment directly after the body counts as missing blank line as well, unless
+// -check-case-comments is disabled. Empty clause bodies are skipped.
+func (n *newlineafterblock) findClauseGap(pass *analysis.Pass, astFile *ast.File, body []ast.Stmt, nextPos token.Pos) (clauseGap, bool) {
+	// Skip empty clauses (no body statements).
+	if len(body) == 0 {
+		return clauseGap{}, false
+	}
+
+	lastStmt := body[len(body)-1]
+	if isBadStmt(lastStmt) {
+		return clauseGap{}, false
 	}
 
-	lastStmt := current.Body[len(current.Body)-1]
 	lastStmtEnd := lastStmt.End()
 
 	file := pass.Fset.File(lastStmtEnd)
 	if file == nil {
-		return
+		return clauseGap{}, false
 	}
 
 	lastStmtLine := file.Line(lastStmtEnd)
-	nextCaseLine := file.Line(next.Pos())
 
-	// Check if there's a comment between the last statement and the next case.
-	foundComment := checkClauseComment(pass, astFile, file, lastStmtEnd, lastStmtLine, next.Pos())
+	// The first non-inline comment between the last statement and the next
+	// clause takes the place of the next clause. Without -check-case-comments
+	// the comment itself separates the clauses.
+	followLine := file.Line(nextPos)
+	if n.checkCaseComments {
+		if commentLine, ok := firstClauseCommentLine(astFile, file, lastStmtEnd, lastStmtLine, nextPos); ok {
+			followLine = commentLine
+		}
+	}
+
+	return clauseGap{end: lastStmtEnd, missing: followLine == lastStmtLine+1}, true
+}
+
+// reportClauseGaps reports the missing blank lines between clauses of a single
+// switch or select statement. With -case-consistency, missing blank lines are
+// only reported if other clauses of the same statement are separated.
+func (n *newlineafterblock) reportClauseGaps(pass *analysis.Pass, gaps []clauseGap) {
+	message := n.message.format("case", "missing newline after case block")
+
+	if n.caseConsistency {
+		separated := slices.ContainsFunc(gaps, func(gap clauseGap) bool { return !gap.missing })
+		if !separated {
+			return
+		}
+
+		message = n.message.formatDetailed("case", "inconsistent blank lines between case blocks")
+	}
+
+	for _, gap := range gaps {
+		if gap.missing {
+			n.reportMissingNewline(pass, gap.end, message)
+		}
+	}
+}
+
+// checkLastCaseBlock checks that a block statement ending the last case clause
+// is followed by a blank line before the closing brace of the switch.
+func (n *newlineafterblock) checkLastCaseBlock(pass *analysis.Pass, last *ast.CaseClause, rbrace token.Pos) {
+	if len(last.Body) == 0 {
+		return
+	}
+
+	// Defer and goto are not blocks, only block statements are relevant here.
+	lastStmt := last.Body[len(last.Body)-1]
+	if isDeferStmt(lastStmt) || isGotoStmt(lastStmt) || !n.needsNewlineAfter(pass, lastStmt) {
+		return
+	}
+
+	blockEnd := getBlockEnd(lastStmt)
+	if blockEnd == token.NoPos || !rbrace.IsValid() {
+		return
+	}
+
+	file := pass.Fset.File(blockEnd)
+	if file == nil {
+		return
+	}
 
-	// If no comment was found, check if the next case is immediately after.
-	if !foundComment && nextCaseLine == lastStmtLine+1 {
-		pass.Report(createDiagnosticWithFix(pass, lastStmtEnd, "missing newline after case block"))
+	if file.Line(rbrace) == file.Line(blockEnd)+1 {
+		n.reportMissingNewline(pass, blockEnd, n.message.format(messageKind(lastStmt), "missing newline after block statement"))
 	}
 }
 
-// checkClauseComment checks for comments between two clause positions and reports violations.
-// Returns true if a non-inline comment was found.
-func checkClauseComment(pass *analysis.Pass, astFile *ast.File, file *token.File, endPos token.Pos, endLine int, nextPos token.Pos) bool {
-	for _, commentGroup := range astFile.Comments {
+// firstClauseCommentLine returns the line of the first non-inline comment
+// between two clause positions.
+func firstClauseCommentLine(astFile *ast.File, file *token.File, endPos token.Pos, endLine int, nextPos token.Pos) (int, bool) {
+	for _, commentGroup := range commentsFrom(astFile, endPos+1) {
 		commentPos := commentGroup.Pos()
-		if commentPos <= endPos || commentPos >= nextPos {
-			continue
+		if commentPos >= nextPos {
+			break
 		}
 
 		commentLine := file.Line(commentPos)
@@ -310,162 +1014,443 @@ func checkClauseComment(pass *analysis.Pass, astFile *ast.File, file *token.File
 			continue
 		}
 
-		// If comment is on the next line (no blank line).
-		if commentLine == endLine+1 {
-			pass.Report(createDiagnosticWithFix(pass, endPos, "missing newline after case block"))
-		}
-
-		// Only check the first non-inline comment.
-		return true
+		return commentLine, true
 	}
 
-	return false
+	return 0, false
 }
 
 // checkCommClauses checks that comm clauses in select statements are properly spaced.
 // Each comm clause (except the last) should be followed by a blank line.
 // CommClause is used for select statements, similar to CaseClause for switch statements.
-func checkCommClauses(pass *analysis.Pass, astFile *ast.File, stmts []ast.Stmt) {
+func (n *newlineafterblock) checkCommClauses(pass *analysis.Pass, astFile *ast.File, stmts []ast.Stmt) {
+	// Compact files relax the missing blank line rule, case clause spacing included.
+	if n.compactFile {
+		return
+	}
+
 	commClauses := extractCommClauses(stmts)
-	if len(commClauses) < 2 {
+	if !n.caseClauses || len(commClauses) < 2 {
 		return
 	}
 
-	// Check spacing between consecutive comm clauses.
-	for i := 0; i < len(commClauses)-1; i++ {
-		checkCommClauseSpacing(pass, astFile, commClauses[i], commClauses[i+1])
+	// Check spacing between consecutive comm clauses.
+	var gaps []clauseGap
+	for i := 0; i < len(commClauses)-1; i++ {
+		if gap, ok := n.findClauseGap(pass, astFile, commClauses[i].Body, commClauses[i+1].Pos()); ok {
+			gaps = append(gaps, gap)
+		}
+	}
+
+	n.reportClauseGaps(pass, gaps)
+}
+
+// extractCommClauses filters statements to only CommClause nodes.
+func extractCommClauses(stmts []ast.Stmt) []*ast.CommClause {
+	var commClauses []*ast.CommClause
+	for _, stmt := range stmts {
+		if commClause, ok := stmt.(*ast.CommClause); ok {
+			commClauses = append(commClauses, commClause)
+		}
+	}
+
+	return commClauses
+}
+
+// checkAssignStmt checks if an assignment statement contains a function literal.
+func checkAssignStmt(s *ast.AssignStmt) *ast.FuncLit {
+	for _, expr := range s.Rhs {
+		if funcLit := extractFuncLit(expr); funcLit != nil {
+			return funcLit
+		}
+	}
+
+	return nil
+}
+
+// checkDeclStmt checks if a declaration statement contains a function literal.
+func checkDeclStmt(s *ast.DeclStmt) *ast.FuncLit {
+	genDecl, ok := s.Decl.(*ast.GenDecl)
+	if !ok {
+		return nil
+	}
+
+	for _, spec := range genDecl.Specs {
+		if funcLit := checkValueSpec(spec); funcLit != nil {
+			return funcLit
+		}
+	}
+
+	return nil
+}
+
+// isTypeDecl checks if a declaration statement declares types.
+func isTypeDecl(s *ast.DeclStmt) bool {
+	genDecl, ok := s.Decl.(*ast.GenDecl)
+	return ok && genDecl.Tok == token.TYPE
+}
+
+// checkValueSpec checks if a value spec contains a function literal.
+func checkValueSpec(spec ast.Spec) *ast.FuncLit {
+	valueSpec, ok := spec.(*ast.ValueSpec)
+	if !ok {
+		return nil
+	}
+
+	for _, value := range valueSpec.Values {
+		if funcLit := extractFuncLit(value); funcLit != nil {
+			return funcLit
+		}
+	}
+
+	return nil
+}
+
+// isNamedFuncLitAssign checks if an assignment assigns a function literal to a
+// named variable, not to the blank identifier, an index or a field.
+func isNamedFuncLitAssign(s *ast.AssignStmt) bool {
+	if len(s.Lhs) != len(s.Rhs) {
+		return false
+	}
+
+	for i, expr := range s.Rhs {
+		if extractFuncLit(expr) != nil && isNamedIdent(s.Lhs[i]) {
+			return true
+		}
+	}
+
+	return false
+}
+
+// isNamedFuncLitDecl checks if a declaration declares a named variable with a
+// function literal as value.
+func isNamedFuncLitDecl(s *ast.DeclStmt) bool {
+	genDecl, ok := s.Decl.(*ast.GenDecl)
+	if !ok {
+		return false
+	}
+
+	for _, spec := range genDecl.Specs {
+		valueSpec, ok := spec.(*ast.ValueSpec)
+		if !ok || len(valueSpec.Names) != len(valueSpec.Values) {
+			continue
+		}
+
+		for i, value := range valueSpec.Values {
+			if extractFuncLit(value) != nil && isNamedIdent(valueSpec.Names[i]) {
+				return true
+			}
+		}
+	}
+
+	return false
+}
+
+// isNamedIdent checks if an expression is an identifier other than the blank identifier.
+func isNamedIdent(expr ast.Expr) bool {
+	ident, ok := expr.(*ast.Ident)
+	return ok && ident.Name != "_"
+}
+
+// extractFuncLit extracts a function literal from an expression.
+// Only returns function literals that are NOT immediately invoked,
+// since invoked function literals end with ), not }.
+func extractFuncLit(expr ast.Expr) *ast.FuncLit {
+	switch e := expr.(type) {
+	case *ast.FuncLit:
+		// Direct function literal: func() {}
+		return e
+
+	case *ast.CallExpr:
+		// Don't flag immediately invoked function literals: func() {}()
+		// These end with ), not }, so they shouldn't require a newline
+		return nil
+	}
+
+	return nil
+}
+
+// trailingFuncLitArg returns the function literal passed as the last argument
+// of a call expression statement, e.g. g.Go(func() error { ... }).
+func trailingFuncLitArg(s *ast.ExprStmt) *ast.FuncLit {
+	call, ok := s.X.(*ast.CallExpr)
+	if !ok || len(call.Args) == 0 {
+		return nil
+	}
+
+	funcLit, ok := call.Args[len(call.Args)-1].(*ast.FuncLit)
+	if !ok {
+		return nil
+	}
+
+	return funcLit
+}
+
+// isMultiLineGoFuncLit checks if a go statement launches a function literal
+// spanning multiple lines, e.g. go func() { ... }(). Other calls like go f()
+// do not end with a block.
+func isMultiLineGoFuncLit(pass *analysis.Pass, s *ast.GoStmt) bool {
+	funcLit, ok := s.Call.Fun.(*ast.FuncLit)
+	return ok && spansMultipleLines(pass, funcLit)
+}
+
+// spansMultipleLines checks if a node starts and ends on different lines.
+func spansMultipleLines(pass *analysis.Pass, node ast.Node) bool {
+	file := pass.Fset.File(node.Pos())
+	if file == nil {
+		return false
+	}
+
+	return file.Line(node.Pos()) != file.Line(node.End())
+}
+
+// needsNewlineAfter determines if a statement needs a newline after it.
+// Labeled statements are treated like the statement they label.
+func (n *newlineafterblock) needsNewlineAfter(pass *analysis.Pass, stmt ast.Stmt) bool {
+	stmt = unlabel(stmt)
+
+	if !n.isBlockRequiringNewline(pass, stmt) {
+		return false
+	}
+
+	// Guard if statements always require a blank line if enabled.
+	if n.strictGuardSpacing && isGuardIfStmt(stmt) {
+		return true
+	}
+
+	kind := blockKind(stmt)
+	if kind != "" && !n.blockKinds.enabled(kind) {
+		return false
+	}
+
+	body := blockBody(stmt)
+	if body == nil {
+		return true
+	}
+
+	// One-line blocks, including if-else chains, are compact enough if enabled.
+	if n.multilineOnly && isSingleLine(pass, body.Lbrace, getBlockEnd(stmt)) {
+		return false
+	}
+
+	return n.minBlockStmts.satisfiedBy(kind, len(body.List))
+}
+
+// isBlockRequiringNewline determines if a statement ends with a block that
+// requires a newline after it, regardless of the size of its body.
+func (n *newlineafterblock) isBlockRequiringNewline(pass *analysis.Pass, stmt ast.Stmt) bool {
+	switch s := stmt.(type) {
+	case *ast.IfStmt, *ast.ForStmt, *ast.RangeStmt:
+		return true
+
+	case *ast.SwitchStmt, *ast.TypeSwitchStmt, *ast.SelectStmt:
+		return true
+
+	case *ast.AssignStmt:
+		return checkAssignStmt(s) != nil && (!n.namedFuncLitOnly || isNamedFuncLitAssign(s))
+
+	case *ast.DeclStmt:
+		// Multi-line type declarations, e.g. of interfaces, are blocks if enabled.
+		if isTypeDecl(s) {
+			return n.typeDecls && spansMultipleLines(pass, s)
+		}
+
+		return checkDeclStmt(s) != nil && (!n.namedFuncLitOnly || isNamedFuncLitDecl(s))
+
+	case *ast.DeferStmt:
+		// Defer statements need newlines when followed by non-defer statements.
+		// The exception (consecutive defers) is handled in checkStatementPair.
+		return true
+
+	case *ast.GoStmt:
+		// Goroutines launched with a multi-line function literal end with }().
+		return isMultiLineGoFuncLit(pass, s)
+
+	case *ast.BlockStmt:
+		// Bare blocks used for scoping are handled like other blocks if enabled.
+		return n.bareBlocks
+
+	case *ast.ExprStmt:
+		// Calls with a trailing multi-line function literal end with }) if enabled.
+		funcLit := trailingFuncLitArg(s)
+		return n.funcLitArgs && funcLit != nil && spansMultipleLines(pass, funcLit)
+
+	case *ast.BranchStmt:
+		// Goto statements are handled like defer statements if enabled.
+		// The exception (consecutive gotos) is handled in checkStatementPair.
+		return n.blankAfterGoto && s.Tok == token.GOTO
 	}
+
+	return false
 }
 
-// extractCommClauses filters statements to only CommClause nodes.
-func extractCommClauses(stmts []ast.Stmt) []*ast.CommClause {
-	var commClauses []*ast.CommClause
-	for _, stmt := range stmts {
-		if commClause, ok := stmt.(*ast.CommClause); ok {
-			commClauses = append(commClauses, commClause)
+// unlabel returns the statement wrapped by (possibly nested) labels, e.g. the
+// for statement of Loop: for { ... }.
+func unlabel(stmt ast.Stmt) ast.Stmt {
+	for {
+		labeled, ok := stmt.(*ast.LabeledStmt)
+		if !ok {
+			return stmt
 		}
-	}
 
-	return commClauses
+		stmt = labeled.Stmt
+	}
 }
 
-// checkCommClauseSpacing checks spacing between two consecutive comm clauses.
-func checkCommClauseSpacing(pass *analysis.Pass, astFile *ast.File, current, next *ast.CommClause) {
-	// Skip empty comm clauses (no body statements).
-	if len(current.Body) == 0 {
-		return
+// messageKind returns the kind of statement for the -message template, the
+// block kind or the kind of the statements that are not blocks themselves.
+func messageKind(stmt ast.Stmt) string {
+	stmt = unlabel(stmt)
+	if kind := blockKind(stmt); kind != "" {
+		return kind
 	}
 
-	lastStmt := current.Body[len(current.Body)-1]
-	lastStmtEnd := lastStmt.End()
+	switch s := stmt.(type) {
+	case *ast.DeclStmt:
+		return "type"
 
-	file := pass.Fset.File(lastStmtEnd)
-	if file == nil {
-		return
-	}
+	case *ast.DeferStmt:
+		return "defer"
 
-	lastStmtLine := file.Line(lastStmtEnd)
-	nextCommLine := file.Line(next.Pos())
+	case *ast.GoStmt:
+		return "go"
 
-	// Check if there's a comment between the last statement and the next comm.
-	foundComment := checkClauseComment(pass, astFile, file, lastStmtEnd, lastStmtLine, next.Pos())
+	case *ast.BranchStmt:
+		return s.Tok.String()
 
-	// If no comment was found, check if the next comm is immediately after.
-	if !foundComment && nextCommLine == lastStmtLine+1 {
-		pass.Report(createDiagnosticWithFix(pass, lastStmtEnd, "missing newline after case block"))
+	case *ast.ExprStmt:
+		return "call"
 	}
+
+	return "block"
 }
 
-// checkAssignStmt checks if an assignment statement contains a function literal.
-func checkAssignStmt(s *ast.AssignStmt) *ast.FuncLit {
-	for _, expr := range s.Rhs {
-		if funcLit := extractFuncLit(expr); funcLit != nil {
-			return funcLit
-		}
+// isSingleLine checks if two positions are on the same line.
+func isSingleLine(pass *analysis.Pass, start, end token.Pos) bool {
+	if !start.IsValid() || !end.IsValid() {
+		return false
 	}
 
-	return nil
+	return pass.Fset.Position(start).Line == pass.Fset.Position(end).Line
 }
 
-// checkDeclStmt checks if a declaration statement contains a function literal.
-func checkDeclStmt(s *ast.DeclStmt) *ast.FuncLit {
-	genDecl, ok := s.Decl.(*ast.GenDecl)
-	if !ok {
-		return nil
-	}
+// blockKind returns the kind of block a statement ends with, as used by the
+// -block-kinds and -min-block-stmts flags. Type switches are of kind "switch",
+// assignments and declarations of function literals of kind "func". Type
+// declarations have no kind.
+func blockKind(stmt ast.Stmt) string {
+	switch s := stmt.(type) {
+	case *ast.IfStmt:
+		return "if"
 
-	for _, spec := range genDecl.Specs {
-		if funcLit := checkValueSpec(spec); funcLit != nil {
-			return funcLit
+	case *ast.ForStmt:
+		return "for"
+
+	case *ast.RangeStmt:
+		return "range"
+
+	case *ast.SwitchStmt, *ast.TypeSwitchStmt:
+		return "switch"
+
+	case *ast.SelectStmt:
+		return "select"
+
+	case *ast.DeclStmt:
+		if isTypeDecl(s) {
+			return ""
 		}
+
+		return "func"
+
+	case *ast.AssignStmt:
+		return "func"
 	}
 
-	return nil
+	return ""
 }
 
-// checkValueSpec checks if a value spec contains a function literal.
-func checkValueSpec(spec ast.Spec) *ast.FuncLit {
-	valueSpec, ok := spec.(*ast.ValueSpec)
-	if !ok {
-		return nil
-	}
+// blockBody returns the body of the block a statement starts with, or nil if
+// the statement has no body of its own. For if statements this is the body of
+// the if branch, for switch and select statements the body holds the clauses.
+// Bare blocks are their own body.
+func blockBody(stmt ast.Stmt) *ast.BlockStmt {
+	switch s := stmt.(type) {
+	case *ast.BlockStmt:
+		return s
 
-	for _, value := range valueSpec.Values {
-		if funcLit := extractFuncLit(value); funcLit != nil {
-			return funcLit
+	case *ast.IfStmt:
+		return s.Body
+
+	case *ast.ForStmt:
+		return s.Body
+
+	case *ast.RangeStmt:
+		return s.Body
+
+	case *ast.SwitchStmt:
+		return s.Body
+
+	case *ast.TypeSwitchStmt:
+		return s.Body
+
+	case *ast.SelectStmt:
+		return s.Body
+
+	case *ast.AssignStmt:
+		if funcLit := checkAssignStmt(s); funcLit != nil {
+			return funcLit.Body
+		}
+
+	case *ast.DeclStmt:
+		if funcLit := checkDeclStmt(s); funcLit != nil {
+			return funcLit.Body
 		}
 	}
 
 	return nil
 }
 
-// extractFuncLit extracts a function literal from an expression.
-// Only returns function literals that are NOT immediately invoked,
-// since invoked function literals end with ), not }.
-func extractFuncLit(expr ast.Expr) *ast.FuncLit {
-	switch e := expr.(type) {
-	case *ast.FuncLit:
-		// Direct function literal: func() {}
-		return e
+// allowsDeferAfter checks if a defer statement may immediately follow stmt.
+func (n *newlineafterblock) allowsDeferAfter(pass *analysis.Pass, stmt ast.Stmt) bool {
+	if n.strictGuardSpacing && isGuardIfStmt(stmt) {
+		return false
+	}
 
-	case *ast.CallExpr:
-		// Don't flag immediately invoked function literals: func() {}()
-		// These end with ), not }, so they shouldn't require a newline
-		return nil
+	if isErrorCheckIfStmt(pass, stmt) {
+		return true
 	}
 
-	return nil
+	return n.deferAnyGuard && isGuardIfStmt(stmt)
 }
 
-// needsNewlineAfter determines if a statement needs a newline after it.
-func needsNewlineAfter(stmt ast.Stmt) bool {
-	switch s := stmt.(type) {
-	case *ast.IfStmt:
-		// If statement with else: check the else branch.
-		// If statement without else: needs newline
-		if s.Else != nil {
-			// The else branch itself needs a newline after it.
-			return true
-		}
-
-		return true
+// isGuardIfStmt checks if an if statement without else has a body consisting of
+// a single terminating statement (return, break, continue, goto or panic).
+func isGuardIfStmt(stmt ast.Stmt) bool {
+	ifStmt, ok := stmt.(*ast.IfStmt)
+	if !ok || ifStmt.Else != nil || ifStmt.Body == nil || len(ifStmt.Body.List) != 1 {
+		return false
+	}
 
-	case *ast.ForStmt, *ast.RangeStmt:
-		return true
+	return isTerminatingStmt(ifStmt.Body.List[0])
+}
 
-	case *ast.SwitchStmt, *ast.TypeSwitchStmt, *ast.SelectStmt:
+// isTerminatingStmt checks if a statement unconditionally leaves the current flow.
+func isTerminatingStmt(stmt ast.Stmt) bool {
+	switch s := stmt.(type) {
+	case *ast.ReturnStmt:
 		return true
 
-	case *ast.AssignStmt:
-		return checkAssignStmt(s) != nil
+	case *ast.BranchStmt:
+		return s.Tok != token.FALLTHROUGH
 
-	case *ast.DeclStmt:
-		return checkDeclStmt(s) != nil
+	case *ast.ExprStmt:
+		call, ok := s.X.(*ast.CallExpr)
+		if !ok {
+			return false
+		}
 
-	case *ast.DeferStmt:
-		// Defer statements need newlines when followed by non-defer statements.
-		// The exception (consecutive defers) is handled in checkStatementPair.
-		return true
+		ident, ok := call.Fun.(*ast.Ident)
+		return ok && ident.Name == "panic"
 	}
 
 	return false
@@ -478,8 +1463,8 @@ func isErrorCheckIfStmt(pass *analysis.Pass, stmt ast.Stmt) bool {
 		return false
 	}
 
-	// Check if the condition is a binary expression.
-	binaryExpr, ok := ifStmt.Cond.(*ast.BinaryExpr)
+	// Check if the condition is a binary expression, possibly in parentheses.
+	binaryExpr, ok := ast.Unparen(ifStmt.Cond).(*ast.BinaryExpr)
 	if !ok {
 		return false
 	}
@@ -493,25 +1478,24 @@ func isErrorCheckIfStmt(pass *analysis.Pass, stmt ast.Stmt) bool {
 	return isErrNotNilPattern(pass, binaryExpr.X, binaryExpr.Y) || isErrNotNilPattern(pass, binaryExpr.Y, binaryExpr.X)
 }
 
-// isErrNotNilPattern checks if x is a variable implementing the error interface and y is nil.
+// isErrNotNilPattern checks if x is a variable or struct field implementing the error interface and y is nil
+// or a typed nil conversion.
+// Both operands may be wrapped in parentheses.
 func isErrNotNilPattern(pass *analysis.Pass, x, y ast.Expr) bool {
-	ident, ok := x.(*ast.Ident)
-	if !ok {
-		return false
-	}
+	x, y = ast.Unparen(x), ast.Unparen(y)
 
-	// Check if y is nil.
-	nilIdent, ok := y.(*ast.Ident)
-	if !ok || nilIdent.Name != "nil" {
+	switch x.(type) {
+	case *ast.Ident, *ast.SelectorExpr:
+	default:
 		return false
 	}
 
-	// Check if x has a type that implements the error interface.
-	if pass.TypesInfo == nil {
+	// Check if x has a type that implements the error interface and y is nil.
+	if pass.TypesInfo == nil || !isNilExpr(pass, y) {
 		return false
 	}
 
-	typ := pass.TypesInfo.TypeOf(ident)
+	typ := pass.TypesInfo.TypeOf(x)
 	if typ == nil {
 		return false
 	}
@@ -520,6 +1504,24 @@ func isErrNotNilPattern(pass *analysis.Pass, x, y ast.Expr) bool {
 	return implementsError(typ)
 }
 
+// isNilExpr checks if an expression is nil, either the bare nil identifier or
+// a typed nil conversion like error(nil) or (*T)(nil).
+func isNilExpr(pass *analysis.Pass, expr ast.Expr) bool {
+	switch e := ast.Unparen(expr).(type) {
+	case *ast.Ident:
+		return e.Name == "nil"
+
+	case *ast.CallExpr:
+		if len(e.Args) != 1 || !pass.TypesInfo.Types[e.Fun].IsType() {
+			return false
+		}
+
+		return isNilExpr(pass, e.Args[0])
+	}
+
+	return false
+}
+
 // implementsError checks if a type implements the error interface using types.Implements.
 func implementsError(typ types.Type) bool {
 	errorObj := types.Universe.Lookup("error")
@@ -561,6 +1563,27 @@ func isDeferStmt(stmt ast.Stmt) bool {
 	return ok
 }
 
+// isGoDeferPair checks if one of two statements is a go statement and the other
+// one a defer statement, in either order.
+func isGoDeferPair(current, next ast.Stmt) bool {
+	_, currentGo := current.(*ast.GoStmt)
+	_, nextGo := next.(*ast.GoStmt)
+
+	return (currentGo && isDeferStmt(next)) || (isDeferStmt(current) && nextGo)
+}
+
+// isGotoStmt checks if a statement is a goto statement.
+func isGotoStmt(stmt ast.Stmt) bool {
+	branchStmt, ok := stmt.(*ast.BranchStmt)
+	return ok && branchStmt.Tok == token.GOTO
+}
+
+// isBadStmt checks if a statement is a placeholder for source that failed to parse.
+func isBadStmt(stmt ast.Stmt) bool {
+	_, ok := stmt.(*ast.BadStmt)
+	return ok
+}
+
 // getBlockEnd returns the end position of a block statement's body.
 func getBlockEnd(stmt ast.Stmt) token.Pos {
 	switch s := stmt.(type) {
@@ -571,60 +1594,96 @@ func getBlockEnd(stmt ast.Stmt) token.Pos {
 		}
 
 		// Otherwise return the end of the if body.
-		if s.Body != nil {
-			return s.Body.End()
-		}
+		return blockStmtEnd(s.Body)
 
 	case *ast.BlockStmt:
 		// Handle else blocks (which are BlockStmt nodes).
-		return s.End()
+		return blockStmtEnd(s)
+
+	case *ast.LabeledStmt:
+		// For labeled statements, return the end of the labeled statement.
+		return getBlockEnd(s.Stmt)
 
 	case *ast.ForStmt:
-		if s.Body != nil {
-			return s.Body.End()
-		}
+		return blockStmtEnd(s.Body)
 
 	case *ast.RangeStmt:
-		if s.Body != nil {
-			return s.Body.End()
-		}
+		return blockStmtEnd(s.Body)
 
 	case *ast.SwitchStmt:
-		if s.Body != nil {
-			return s.Body.End()
-		}
+		return blockStmtEnd(s.Body)
 
 	case *ast.TypeSwitchStmt:
-		if s.Body != nil {
-			return s.Body.End()
-		}
+		return blockStmtEnd(s.Body)
 
 	case *ast.SelectStmt:
-		if s.Body != nil {
-			return s.Body.End()
-		}
+		return blockStmtEnd(s.Body)
 
 	case *ast.AssignStmt:
-		if funcLit := checkAssignStmt(s); funcLit != nil && funcLit.Body != nil {
-			return funcLit.Body.End()
+		if funcLit := checkAssignStmt(s); funcLit != nil {
+			return blockStmtEnd(funcLit.Body)
 		}
 
 	case *ast.DeclStmt:
-		if funcLit := checkDeclStmt(s); funcLit != nil && funcLit.Body != nil {
-			return funcLit.Body.End()
+		if funcLit := checkDeclStmt(s); funcLit != nil {
+			return blockStmtEnd(funcLit.Body)
+		}
+
+		// For type declarations, return the end of the declaration.
+		if isTypeDecl(s) {
+			return s.End()
 		}
 
 	case *ast.DeferStmt:
 		// For defer statements, return the end position of the statement.
 		return s.End()
+
+	case *ast.GoStmt:
+		// For goroutines launched with a function literal, return the end of the call.
+		if _, ok := s.Call.Fun.(*ast.FuncLit); ok {
+			return s.End()
+		}
+
+	case *ast.ExprStmt:
+		// For calls with a trailing function literal, return the end of the call.
+		if funcLit := trailingFuncLitArg(s); funcLit != nil && blockStmtEnd(funcLit.Body) != token.NoPos {
+			return s.End()
+		}
+
+	case *ast.BranchStmt:
+		if s.Tok == token.GOTO {
+			return s.End()
+		}
 	}
 
 	return token.NoPos
 }
 
+// blockStmtEnd returns the position right after the closing brace of a block.
+// Blocks without a closing brace, which only occur in files with syntax errors,
+// have no well-defined end and yield token.NoPos.
+func blockStmtEnd(block *ast.BlockStmt) token.Pos {
+	if block == nil || !block.Rbrace.IsValid() {
+		return token.NoPos
+	}
+
+	return block.End()
+}
+
 // findEndOfLine returns the position at the end of the line containing pos.
 // This handles inline comments automatically since we insert at end of current line.
-func findEndOfLine(file *token.File, pos token.Pos) token.Pos {
+// If the file content is available, the newline is located in the bytes of the
+// file, otherwise the line table of the file set is used.
+func findEndOfLine(file *token.File, content []byte, pos token.Pos) token.Pos {
+	if content != nil {
+		offset := file.Offset(pos)
+		if i := bytes.IndexByte(content[offset:], '\n'); i >= 0 {
+			return file.Pos(offset + i + 1)
+		}
+
+		return file.Pos(len(content))
+	}
+
 	line := file.Line(pos)
 
 	// If not the last line, return the start of the next line
@@ -637,8 +1696,116 @@ func findEndOfLine(file *token.File, pos token.Pos) token.Pos {
 	return token.Pos(file.Base() + file.Size())
 }
 
+// lineEnding returns the dominant line ending of a file, "\r\n" if most lines
+// end with CRLF and "\n" otherwise, e.g. if the content is not available.
+func lineEnding(content []byte) string {
+	if 2*bytes.Count(content, []byte("\r\n")) > bytes.Count(content, []byte("\n")) {
+		return "\r\n"
+	}
+
+	return "\n"
+}
+
+// readFile returns the content of a file using pass.ReadFile. It returns nil if
+// pass.ReadFile is not available, e.g. for passes not created by a driver, or
+// if the content does not match the file in the file set.
+func readFile(pass *analysis.Pass, file *token.File) []byte {
+	if pass.ReadFile == nil {
+		return nil
+	}
+
+	content, err := pass.ReadFile(file.Name())
+	if err != nil || len(content) != file.Size() {
+		return nil
+	}
+
+	return content
+}
+
+// sourceFile holds the content of a file and its dominant line ending.
+type sourceFile struct {
+	content []byte
+	newline string
+}
+
+// sourceFiles caches the content of the files of a pass, so each file is read
+// and scanned for its line ending at most once per run.
+type sourceFiles struct {
+	pass  *analysis.Pass
+	files map[*token.File]sourceFile
+}
+
+// newSourceFiles creates an empty cache for the files of pass.
+func newSourceFiles(pass *analysis.Pass) *sourceFiles {
+	return &sourceFiles{
+		pass:  pass,
+		files: make(map[*token.File]sourceFile),
+	}
+}
+
+// get returns the content of file, which is read on first use. Without file
+// the content is nil and the line ending "\n".
+func (s *sourceFiles) get(file *token.File) sourceFile {
+	if file == nil {
+		return sourceFile{newline: lineEnding(nil)}
+	}
+
+	if src, ok := s.files[file]; ok {
+		return src
+	}
+
+	content := readFile(s.pass, file)
+	src := sourceFile{content: content, newline: lineEnding(content)}
+	s.files[file] = src
+
+	return src
+}
+
+// isBlank checks if content only consists of whitespace, including line breaks.
+func isBlank(content []byte) bool {
+	return len(bytes.TrimLeft(content, " \t\r\n")) == 0
+}
+
+// reportMissingNewline reports a missing blank line after blockEnd. With
+// -report-at-next, the diagnostic points at the start of the following line
+// instead of the block end, the suggested fix stays the same. A //nolint
+// directive on the line of the block end suppresses the diagnostic in both
+// cases.
+func (n *newlineafterblock) reportMissingNewline(pass *analysis.Pass, blockEnd token.Pos, message string) {
+	file := pass.Fset.File(blockEnd)
+	src := n.sources.get(file)
+	diagnostic := createDiagnosticWithFix(pass, src, blockEnd, message)
+
+	if n.reportAtNext && file != nil {
+		if n.nolint.suppresses(pass.Fset.Position(blockEnd)) {
+			return
+		}
+
+		diagnostic.Pos = findNextLineStart(file, src.content, blockEnd)
+	}
+
+	pass.Report(diagnostic)
+}
+
+// findNextLineStart returns the position of the first non-blank character of
+// the line following pos, or the start of that line if the content is not
+// available.
+func findNextLineStart(file *token.File, content []byte, pos token.Pos) token.Pos {
+	start := findEndOfLine(file, content, pos)
+	if content == nil {
+		return start
+	}
+
+	offset := file.Offset(start)
+	for offset < len(content) && (content[offset] == ' ' || content[offset] == '\t') {
+		offset++
+	}
+
+	return file.Pos(offset)
+}
+
 // createDiagnosticWithFix creates a diagnostic with a suggested fix to insert a blank line.
-func createDiagnosticWithFix(pass *analysis.Pass, blockEnd token.Pos, message string) analysis.Diagnostic {
+func createDiagnosticWithFix(pass *analysis.Pass, src sourceFile, blockEnd token.Pos, message string) analysis.Diagnostic {
 	file := pass.Fset.File(blockEnd)
 	if file == nil {
 		// Fallback: return diagnostic without fix
@@ -649,7 +1816,7 @@ func createDiagnosticWithFix(pass *analysis.Pass, blockEnd token.Pos, message st
 	}
 
 	// Find the end of the line containing blockEnd
-	insertPos := findEndOfLine(file, blockEnd)
+	insertPos := findEndOfLine(file, src.content, blockEnd)
 
 	return analysis.Diagnostic{
 		Pos:     blockEnd,
@@ -661,10 +1828,94 @@ func createDiagnosticWithFix(pass *analysis.Pass, blockEnd token.Pos, message st
 					{
 						Pos:     insertPos,
 						End:     insertPos,
-						NewText: []byte("\n"),
+						NewText: []byte(src.newline),
+					},
+				},
+			},
+		},
+	}
+}
+
+// createDiagnosticWithBeforeFix creates a diagnostic at the start of a block
+// statement with a suggested fix to insert a blank line after the previous
+// statement.
+func createDiagnosticWithBeforeFix(file *token.File, src sourceFile, prevEnd, blockPos token.Pos, message string) analysis.Diagnostic {
+	insertPos := findEndOfLine(file, src.content, prevEnd)
+
+	return analysis.Diagnostic{
+		Pos:     blockPos,
+		Message: message,
+		SuggestedFixes: []analysis.SuggestedFix{
+			{
+				Message: "Insert blank line before block statement",
+				TextEdits: []analysis.TextEdit{
+					{
+						Pos:     insertPos,
+						End:     insertPos,
+						NewText: []byte(src.newline),
+					},
+				},
+			},
+		},
+	}
+}
+
+// createDiagnosticWithSplitFix creates a diagnostic with a suggested fix to move
+// a statement on the same line as the end of a block to its own line, preceded
+// by a blank line. If the file content is available, the semicolons and spaces
+// directly before the statement are removed as well.
+func createDiagnosticWithSplitFix(file *token.File, src sourceFile, blockEnd, nextPos token.Pos, indent, message string) analysis.Diagnostic {
+	start := nextPos
+	if src.content != nil {
+		offset := file.Offset(nextPos)
+		for offset > file.Offset(blockEnd) && bytes.IndexByte([]byte(" \t;"), src.content[offset-1]) >= 0 {
+			offset--
+		}
+
+		start = file.Pos(offset)
+	}
+
+	return analysis.Diagnostic{
+		Pos:     blockEnd,
+		Message: message,
+		SuggestedFixes: []analysis.SuggestedFix{
+			{
+				Message: "Move statement after a blank line",
+				TextEdits: []analysis.TextEdit{
+					{
+						Pos:     start,
+						End:     nextPos,
+						NewText: []byte(src.newline + src.newline + indent),
 					},
 				},
 			},
 		},
 	}
 }
+
+// createDiagnosticWithRemovalFix creates a diagnostic with a suggested fix to remove surplus blank lines.
+// If the file content is available, the fix is only suggested if the removed range is blank.
+func createDiagnosticWithRemovalFix(file *token.File, src sourceFile, blockEnd, start, end token.Pos, message string) analysis.Diagnostic {
+	diagnostic := analysis.Diagnostic{
+		Pos:     blockEnd,
+		Message: message,
+	}
+
+	if src.content != nil && !isBlank(src.content[file.Offset(start):file.Offset(end)]) {
+		return diagnostic
+	}
+
+	diagnostic.SuggestedFixes = []analysis.SuggestedFix{
+		{
+			Message: "Remove surplus blank lines after block statement",
+			TextEdits: []analysis.TextEdit{
+				{
+					Pos: start,
+					End: end,
+				},
+			},
+		},
+	}
+
+	return diagnostic
+}
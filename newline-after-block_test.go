@@ -1,8 +1,17 @@
 package newlineafterblock_test
 
 import (
+	"cmp"
+	"fmt"
+	"go/ast"
+	"go/parser"
+	"go/token"
+	"os"
+	"slices"
+	"strings"
 	"testing"
 
+	"golang.org/x/tools/go/analysis"
 	"golang.org/x/tools/go/analysis/analysistest"
 
 	newlineafterblock "github.com/breml/newline-after-block"
@@ -21,6 +30,11 @@ func TestAnalyzer(t *testing.T) {
 	analysistest.Run(t, testdata, analyzer, "blockstatements")
 }
 
+func TestPackageAnalyzer(t *testing.T) {
+	testdata := analysistest.TestData()
+	analysistest.Run(t, testdata, newlineafterblock.Analyzer, "structliterals")
+}
+
 func TestAnalyzerStructLiterals(t *testing.T) {
 	analyzer := newlineafterblock.New()
 
@@ -28,6 +42,34 @@ func TestAnalyzerStructLiterals(t *testing.T) {
 	analysistest.Run(t, testdata, analyzer, "structliterals")
 }
 
+func TestAnalyzerPackageComment(t *testing.T) {
+	analyzer := newlineafterblock.New()
+
+	testdata := analysistest.TestData()
+	analysistest.Run(t, testdata, analyzer, "packagecomment")
+}
+
+func TestAnalyzerNolint(t *testing.T) {
+	analyzer := newlineafterblock.New()
+
+	testdata := analysistest.TestData()
+	analysistest.Run(t, testdata, analyzer, "nolint")
+}
+
+func TestAnalyzerDisabledRegions(t *testing.T) {
+	analyzer := newlineafterblock.New()
+
+	testdata := analysistest.TestData()
+	analysistest.Run(t, testdata, analyzer, "disabledregions")
+}
+
+func TestAnalyzerGoStatements(t *testing.T) {
+	analyzer := newlineafterblock.New()
+
+	testdata := analysistest.TestData()
+	analysistest.Run(t, testdata, analyzer, "gostatements")
+}
+
 func TestAnalyzerComments(t *testing.T) {
 	analyzer := newlineafterblock.New()
 
@@ -62,6 +104,34 @@ func TestAnalyzerStructLiteralsWithFixes(t *testing.T) {
 	analysistest.RunWithSuggestedFixes(t, testdata, analyzer, "structliterals")
 }
 
+func TestAnalyzerPackageCommentWithFixes(t *testing.T) {
+	analyzer := newlineafterblock.New()
+
+	testdata := analysistest.TestData()
+	analysistest.RunWithSuggestedFixes(t, testdata, analyzer, "packagecomment")
+}
+
+func TestAnalyzerNolintWithFixes(t *testing.T) {
+	analyzer := newlineafterblock.New()
+
+	testdata := analysistest.TestData()
+	analysistest.RunWithSuggestedFixes(t, testdata, analyzer, "nolint")
+}
+
+func TestAnalyzerDisabledRegionsWithFixes(t *testing.T) {
+	analyzer := newlineafterblock.New()
+
+	testdata := analysistest.TestData()
+	analysistest.RunWithSuggestedFixes(t, testdata, analyzer, "disabledregions")
+}
+
+func TestAnalyzerGoStatementsWithFixes(t *testing.T) {
+	analyzer := newlineafterblock.New()
+
+	testdata := analysistest.TestData()
+	analysistest.RunWithSuggestedFixes(t, testdata, analyzer, "gostatements")
+}
+
 func TestAnalyzerCommentsWithFixes(t *testing.T) {
 	analyzer := newlineafterblock.New()
 
@@ -103,3 +173,1284 @@ func TestAnalyzerDeferPatternWithFixes(t *testing.T) {
 	testdata := analysistest.TestData()
 	analysistest.RunWithSuggestedFixes(t, testdata, analyzer, "deferpattern")
 }
+
+func TestAnalyzerSyntaxErrors(t *testing.T) {
+	// analysistest requires valid code, so the broken files are parsed directly
+	// and the analyzer is run on the resulting partial ASTs.
+	sources := map[string]string{
+		"unterminated block": "package broken\nfunc f() {\n\tif x {\n\t\ty()\n",
+		"unterminated call":  "package broken\nfunc f() {\n\tif x {\n\t}\n\tz(\n\tfor {\n",
+		"broken case":        "package broken\nfunc f() {\n\tswitch x {\n\tcase 1:\n\t\tif {\n\tcase 2:\n\t}\n\tfoo()\n}\n",
+		"broken comm clause": "package broken\nfunc f() {\n\tselect {\n\tcase <-:\n\t\tx()\n\tcase\n\t}\n}\n",
+		"dangling else":      "package broken\nfunc f() {\n\tif x {\n\t} else\n\tx()\n}\n",
+		"dangling defer":     "package broken\nfunc f() {\n\tif err != nil {\n\t}\n\tdefer f(\n",
+	}
+
+	for name, src := range sources {
+		t.Run(name, func(t *testing.T) {
+			fset := token.NewFileSet()
+			file, err := parser.ParseFile(fset, "broken.go", src, parser.ParseComments)
+			if err == nil {
+				t.Fatal("expected a syntax error")
+			}
+
+			pass := &analysis.Pass{
+				Fset:   fset,
+				Files:  []*ast.File{file},
+				Report: func(analysis.Diagnostic) {},
+			}
+
+			_, err = newlineafterblock.New().Run(pass)
+			if err != nil {
+				t.Fatalf("unexpected error: %v", err)
+			}
+		})
+	}
+}
+
+func TestAnalyzerFixesFromFileContent(t *testing.T) {
+	tests := map[string]struct {
+		src  string
+		want string
+	}{
+		"missing blank line": {
+			src:  "package p\nfunc f() {\n\tif x {\n\t}\n\ty()\n}\n",
+			want: "package p\nfunc f() {\n\tif x {\n\t}\n\n\ty()\n}\n",
+		},
+		"missing blank line with inline comment": {
+			src:  "package p\nfunc f() {\n\tif x {\n\t} // c\n\ty()\n}\n",
+			want: "package p\nfunc f() {\n\tif x {\n\t} // c\n\n\ty()\n}\n",
+		},
+		"surplus blank lines": {
+			src:  "package p\nfunc f() {\n\tif x {\n\t}\n\n\n\n\ty()\n}\n",
+			want: "package p\nfunc f() {\n\tif x {\n\t}\n\n\ty()\n}\n",
+		},
+		"surplus blank lines with whitespace": {
+			src:  "package p\nfunc f() {\n\tif x {\n\t}\n\t\n  \n\n\ty()\n}\n",
+			want: "package p\nfunc f() {\n\tif x {\n\t}\n\t\n\ty()\n}\n",
+		},
+		"surplus blank lines before comment": {
+			src:  "package p\nfunc f() {\n\tif x {\n\t}\n\n\n\t// c\n\ty()\n}\n",
+			want: "package p\nfunc f() {\n\tif x {\n\t}\n\n\t// c\n\ty()\n}\n",
+		},
+		"comment at end of file without trailing newline": {
+			src:  "package p\nfunc f() {\n\tif x {\n\t}\n\t// c\n}",
+			want: "package p\nfunc f() {\n\tif x {\n\t}\n\n\t// c\n}",
+		},
+		"missing blank line with crlf": {
+			src:  "package p\r\nfunc f() {\r\n\tif x {\r\n\t}\r\n\ty()\r\n}\r\n",
+			want: "package p\r\nfunc f() {\r\n\tif x {\r\n\t}\r\n\r\n\ty()\r\n}\r\n",
+		},
+		"missing blank line with inline comment and crlf": {
+			src:  "package p\r\nfunc f() {\r\n\tif x {\r\n\t} // c\r\n\ty()\r\n}\r\n",
+			want: "package p\r\nfunc f() {\r\n\tif x {\r\n\t} // c\r\n\r\n\ty()\r\n}\r\n",
+		},
+		"surplus blank lines with crlf": {
+			src:  "package p\r\nfunc f() {\r\n\tif x {\r\n\t}\r\n\r\n\r\n\ty()\r\n}\r\n",
+			want: "package p\r\nfunc f() {\r\n\tif x {\r\n\t}\r\n\r\n\ty()\r\n}\r\n",
+		},
+		"missing blank line with mostly lf": {
+			src:  "package p\r\nfunc f() {\n\tif x {\n\t}\n\ty()\n}\n",
+			want: "package p\r\nfunc f() {\n\tif x {\n\t}\n\n\ty()\n}\n",
+		},
+	}
+
+	for name, tc := range tests {
+		t.Run(name, func(t *testing.T) {
+			got := applyFixes(t, tc.src, "normalize")
+			if got != tc.want {
+				t.Fatalf("unexpected fixed content:\ngot:  %q\nwant: %q", got, tc.want)
+			}
+
+			// Fixes are idempotent, the fixed content has nothing left to fix.
+			if again := applyFixes(t, got, "normalize"); again != got {
+				t.Fatalf("fixes are not idempotent:\nfirst:  %q\nsecond: %q", got, again)
+			}
+		})
+	}
+}
+
+func TestAnalyzerSameLineStatementFixFromFileContent(t *testing.T) {
+	tests := map[string]struct {
+		src  string
+		want string
+	}{
+		"statement after semicolon": {
+			src:  "package p\nfunc f() {\n\tif x {\n\t}; y()\n}\n",
+			want: "package p\nfunc f() {\n\tif x {\n\t}\n\n\ty()\n}\n",
+		},
+		"statement after semicolon and spaces": {
+			src:  "package p\nfunc f() {\n\tif x {\n\t}  ;  y()\n}\n",
+			want: "package p\nfunc f() {\n\tif x {\n\t}\n\n\ty()\n}\n",
+		},
+		"statement after defer": {
+			src:  "package p\nfunc f() {\n\tdefer x(); y()\n}\n",
+			want: "package p\nfunc f() {\n\tdefer x()\n\n\ty()\n}\n",
+		},
+		"statement after a comment": {
+			src:  "package p\nfunc f() {\n\tif x {\n\t} /* c */; y()\n}\n",
+			want: "package p\nfunc f() {\n\tif x {\n\t} /* c */\n\n\ty()\n}\n",
+		},
+	}
+
+	for name, tc := range tests {
+		t.Run(name, func(t *testing.T) {
+			got := applyFixes(t, tc.src, "flag-same-line-statement")
+			if got != tc.want {
+				t.Fatalf("unexpected fixed content:\ngot:  %q\nwant: %q", got, tc.want)
+			}
+		})
+	}
+}
+
+// applyFixes runs the analyzer with the given boolean flags set on src, with
+// pass.ReadFile providing the file content, and applies all suggested fixes.
+func applyFixes(t *testing.T, src string, flags ...string) string {
+	t.Helper()
+
+	fset := token.NewFileSet()
+
+	file, err := parser.ParseFile(fset, "p.go", src, parser.ParseComments)
+	if err != nil {
+		t.Fatalf("failed to parse source: %v", err)
+	}
+
+	var edits []analysis.TextEdit
+
+	pass := &analysis.Pass{
+		Fset:  fset,
+		Files: []*ast.File{file},
+		ReadFile: func(filename string) ([]byte, error) {
+			if filename != "p.go" {
+				return nil, os.ErrNotExist
+			}
+
+			return []byte(src), nil
+		},
+		Report: func(diagnostic analysis.Diagnostic) {
+			for _, fix := range diagnostic.SuggestedFixes {
+				edits = append(edits, fix.TextEdits...)
+			}
+		},
+	}
+
+	analyzer := newlineafterblock.New()
+
+	for _, flag := range flags {
+		err = analyzer.Flags.Set(flag, "true")
+		if err != nil {
+			t.Fatalf("failed to set %s flag: %v", flag, err)
+		}
+	}
+
+	_, err = analyzer.Run(pass)
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	// Apply the edits back to front, so the offsets of earlier edits stay valid.
+	slices.SortFunc(edits, func(a, b analysis.TextEdit) int {
+		return cmp.Compare(b.Pos, a.Pos)
+	})
+
+	tokFile := fset.File(file.Pos())
+	fixed := src
+
+	for _, edit := range edits {
+		start, end := tokFile.Offset(edit.Pos), tokFile.Offset(edit.End)
+		fixed = fixed[:start] + string(edit.NewText) + fixed[end:]
+	}
+
+	return fixed
+}
+
+func TestAnalyzerReadsFilesOnce(t *testing.T) {
+	src := "package p\r\n\r\nfunc f(x int) {\r\n" +
+		"\tif x > 0 {\r\n\t\tx--\r\n\t}\r\n\tx++\r\n" +
+		"\tfor x > 0 {\r\n\t\tx--\r\n\t}\r\n\r\n\r\n\tx++\r\n" +
+		"\tswitch x {\r\n\tcase 1:\r\n\t\tx++\r\n\tdefault:\r\n\t}\r\n\tx++\r\n}\r\n"
+
+	fset := token.NewFileSet()
+
+	file, err := parser.ParseFile(fset, "p.go", src, parser.ParseComments)
+	if err != nil {
+		t.Fatalf("failed to parse source: %v", err)
+	}
+
+	reads := 0
+	diagnostics := 0
+
+	pass := &analysis.Pass{
+		Fset:  fset,
+		Files: []*ast.File{file},
+		ReadFile: func(string) ([]byte, error) {
+			reads++
+			return []byte(src), nil
+		},
+		Report: func(analysis.Diagnostic) {
+			diagnostics++
+		},
+	}
+
+	analyzer := newlineafterblock.New()
+
+	for name, value := range map[string]string{"normalize": "true", "report-at-next": "true"} {
+		err = analyzer.Flags.Set(name, value)
+		if err != nil {
+			t.Fatalf("failed to set %s flag: %v", name, err)
+		}
+	}
+
+	_, err = analyzer.Run(pass)
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	if diagnostics < 3 {
+		t.Fatalf("expected at least 3 diagnostics, got %d", diagnostics)
+	}
+
+	if reads != 1 {
+		t.Errorf("expected the file to be read once, got %d reads", reads)
+	}
+}
+
+// commentHeavySwitch returns the source of a file with a switch statement with
+// the given number of cases and many unrelated comments. A comment sits between
+// every two cases, directly after the case body for every other case.
+func commentHeavySwitch(cases int) string {
+	var src strings.Builder
+
+	src.WriteString("package p\n\nfunc f(x int) {\n\tswitch x {\n")
+
+	for i := range cases {
+		fmt.Fprintf(&src, "\tcase %d:\n\t\ty() // inline comment %d\n", i, i)
+
+		if i%2 == 1 {
+			src.WriteString("\n")
+		}
+
+		fmt.Fprintf(&src, "\t// comment before case %d\n", i+1)
+	}
+
+	src.WriteString("\t}\n}\n\nfunc g() {\n")
+
+	for i := range cases * 10 {
+		fmt.Fprintf(&src, "\t// unrelated comment %d\n\ty()\n\n", i)
+	}
+
+	src.WriteString("}\n")
+
+	return src.String()
+}
+
+// runOnSource runs the analyzer on a single file without type information and
+// returns the reported diagnostics.
+func runOnSource(tb testing.TB, fset *token.FileSet, file *ast.File) []analysis.Diagnostic {
+	tb.Helper()
+
+	var diagnostics []analysis.Diagnostic
+
+	pass := &analysis.Pass{
+		Fset:   fset,
+		Files:  []*ast.File{file},
+		Report: func(diagnostic analysis.Diagnostic) { diagnostics = append(diagnostics, diagnostic) },
+	}
+
+	_, err := newlineafterblock.New().Run(pass)
+	if err != nil {
+		tb.Fatalf("unexpected error: %v", err)
+	}
+
+	return diagnostics
+}
+
+func TestAnalyzerCommentHeavySwitch(t *testing.T) {
+	const cases = 100
+
+	fset := token.NewFileSet()
+
+	file, err := parser.ParseFile(fset, "p.go", commentHeavySwitch(cases), parser.ParseComments)
+	if err != nil {
+		t.Fatalf("failed to parse source: %v", err)
+	}
+
+	// Every even case is directly followed by the comment before the next case,
+	// the last case is not followed by another case. The diagnostic is reported
+	// at the end of the case body, after "\t\ty()". The switch starts at line 4,
+	// each case takes three lines plus a blank line for the odd ones.
+	var want []string
+
+	line := 5
+	for i := range cases {
+		if i%2 == 0 {
+			want = append(want, fmt.Sprintf("p.go:%d:6: missing newline after case block", line+1))
+		}
+
+		line += 3 + i%2
+	}
+
+	var got []string
+	for _, diagnostic := range runOnSource(t, fset, file) {
+		got = append(got, fmt.Sprintf("%s: %s", fset.Position(diagnostic.Pos), diagnostic.Message))
+	}
+
+	if !slices.Equal(got, want) {
+		t.Fatalf("unexpected diagnostics:\ngot:  %q\nwant: %q", got, want)
+	}
+}
+
+func BenchmarkAnalyzerCommentHeavySwitch(b *testing.B) {
+	fset := token.NewFileSet()
+
+	file, err := parser.ParseFile(fset, "p.go", commentHeavySwitch(1000), parser.ParseComments)
+	if err != nil {
+		b.Fatalf("failed to parse source: %v", err)
+	}
+
+	for b.Loop() {
+		runOnSource(b, fset, file)
+	}
+}
+
+func TestAnalyzerLastCaseBlock(t *testing.T) {
+	analyzer := newlineafterblock.New()
+
+	err := analyzer.Flags.Set("last-case-block", "true")
+	if err != nil {
+		t.Fatalf("failed to set last-case-block flag: %v", err)
+	}
+
+	testdata := analysistest.TestData()
+	analysistest.Run(t, testdata, analyzer, "lastcaseblock")
+}
+
+func TestAnalyzerLastCaseBlockWithFixes(t *testing.T) {
+	analyzer := newlineafterblock.New()
+
+	err := analyzer.Flags.Set("last-case-block", "true")
+	if err != nil {
+		t.Fatalf("failed to set last-case-block flag: %v", err)
+	}
+
+	testdata := analysistest.TestData()
+	analysistest.RunWithSuggestedFixes(t, testdata, analyzer, "lastcaseblock")
+}
+
+func TestAnalyzerGotoStatements(t *testing.T) {
+	analyzer := newlineafterblock.New()
+
+	err := analyzer.Flags.Set("blank-after-goto", "true")
+	if err != nil {
+		t.Fatalf("failed to set blank-after-goto flag: %v", err)
+	}
+
+	testdata := analysistest.TestData()
+	analysistest.Run(t, testdata, analyzer, "gotostatements")
+}
+
+func TestAnalyzerGotoStatementsWithFixes(t *testing.T) {
+	analyzer := newlineafterblock.New()
+
+	err := analyzer.Flags.Set("blank-after-goto", "true")
+	if err != nil {
+		t.Fatalf("failed to set blank-after-goto flag: %v", err)
+	}
+
+	testdata := analysistest.TestData()
+	analysistest.RunWithSuggestedFixes(t, testdata, analyzer, "gotostatements")
+}
+
+func TestAnalyzerCompactFiles(t *testing.T) {
+	analyzer := newlineafterblock.New()
+
+	err := analyzer.Flags.Set("compact-files", `.*_compact\.go`)
+	if err != nil {
+		t.Fatalf("failed to set compact-files flag: %v", err)
+	}
+
+	// Surplus blank lines are still reported in compact files.
+	err = analyzer.Flags.Set("normalize", "true")
+	if err != nil {
+		t.Fatalf("failed to set normalize flag: %v", err)
+	}
+
+	testdata := analysistest.TestData()
+	analysistest.Run(t, testdata, analyzer, "compactfiles")
+}
+
+func TestAnalyzerCompactFilesWithFixes(t *testing.T) {
+	analyzer := newlineafterblock.New()
+
+	err := analyzer.Flags.Set("compact-files", `.*_compact\.go`)
+	if err != nil {
+		t.Fatalf("failed to set compact-files flag: %v", err)
+	}
+
+	// Surplus blank lines are still reported in compact files.
+	err = analyzer.Flags.Set("normalize", "true")
+	if err != nil {
+		t.Fatalf("failed to set normalize flag: %v", err)
+	}
+
+	testdata := analysistest.TestData()
+	analysistest.RunWithSuggestedFixes(t, testdata, analyzer, "compactfiles")
+}
+
+func TestAnalyzerNormalize(t *testing.T) {
+	analyzer := newlineafterblock.New()
+
+	err := analyzer.Flags.Set("normalize", "true")
+	if err != nil {
+		t.Fatalf("failed to set normalize flag: %v", err)
+	}
+
+	testdata := analysistest.TestData()
+	analysistest.Run(t, testdata, analyzer, "normalize")
+}
+
+func TestAnalyzerNormalizeWithFixes(t *testing.T) {
+	analyzer := newlineafterblock.New()
+
+	err := analyzer.Flags.Set("normalize", "true")
+	if err != nil {
+		t.Fatalf("failed to set normalize flag: %v", err)
+	}
+
+	testdata := analysistest.TestData()
+	analysistest.RunWithSuggestedFixes(t, testdata, analyzer, "normalize")
+}
+
+func TestAnalyzerDeferAnyGuard(t *testing.T) {
+	analyzer := newlineafterblock.New()
+
+	err := analyzer.Flags.Set("defer-exception-any-guard", "true")
+	if err != nil {
+		t.Fatalf("failed to set defer-exception-any-guard flag: %v", err)
+	}
+
+	testdata := analysistest.TestData()
+	analysistest.Run(t, testdata, analyzer, "deferanyguard")
+}
+
+func TestAnalyzerDeferAnyGuardWithFixes(t *testing.T) {
+	analyzer := newlineafterblock.New()
+
+	err := analyzer.Flags.Set("defer-exception-any-guard", "true")
+	if err != nil {
+		t.Fatalf("failed to set defer-exception-any-guard flag: %v", err)
+	}
+
+	testdata := analysistest.TestData()
+	analysistest.RunWithSuggestedFixes(t, testdata, analyzer, "deferanyguard")
+}
+
+func TestAnalyzerFuncLitArgs(t *testing.T) {
+	analyzer := newlineafterblock.New()
+
+	err := analyzer.Flags.Set("check-trailing-funclit-args", "true")
+	if err != nil {
+		t.Fatalf("failed to set check-trailing-funclit-args flag: %v", err)
+	}
+
+	testdata := analysistest.TestData()
+	analysistest.Run(t, testdata, analyzer, "funclitargs")
+}
+
+func TestAnalyzerFuncLitArgsWithFixes(t *testing.T) {
+	analyzer := newlineafterblock.New()
+
+	err := analyzer.Flags.Set("check-trailing-funclit-args", "true")
+	if err != nil {
+		t.Fatalf("failed to set check-trailing-funclit-args flag: %v", err)
+	}
+
+	testdata := analysistest.TestData()
+	analysistest.RunWithSuggestedFixes(t, testdata, analyzer, "funclitargs")
+}
+
+func TestAnalyzerMinBlockStmts(t *testing.T) {
+	analyzer := newlineafterblock.New()
+
+	err := analyzer.Flags.Set("min-block-stmts", "if=2,range=3,switch=2,func=2")
+	if err != nil {
+		t.Fatalf("failed to set min-block-stmts flag: %v", err)
+	}
+
+	testdata := analysistest.TestData()
+	analysistest.Run(t, testdata, analyzer, "minblockstmts")
+}
+
+func TestAnalyzerMinBlockStmtsWithFixes(t *testing.T) {
+	analyzer := newlineafterblock.New()
+
+	err := analyzer.Flags.Set("min-block-stmts", "if=2,range=3,switch=2,func=2")
+	if err != nil {
+		t.Fatalf("failed to set min-block-stmts flag: %v", err)
+	}
+
+	testdata := analysistest.TestData()
+	analysistest.RunWithSuggestedFixes(t, testdata, analyzer, "minblockstmts")
+}
+
+func TestNewWithConfig(t *testing.T) {
+	tests := map[string]struct {
+		cfg newlineafterblock.Config
+		pkg string
+	}{
+		"compact files with normalize": {
+			cfg: newlineafterblock.Config{
+				CompactFilePatterns: []string{`.*_compact\.go`},
+				Normalize:           true,
+			},
+			pkg: "compactfiles",
+		},
+		"min block statements": {
+			cfg: newlineafterblock.Config{
+				MinBlockStmts: map[string]int{"if": 2, "range": 3, "switch": 2, "func": 2},
+			},
+			pkg: "minblockstmts",
+		},
+		"strict defer": {
+			cfg: newlineafterblock.Config{
+				StrictDefer: true,
+			},
+			pkg: "strictdefer",
+		},
+	}
+
+	for name, tc := range tests {
+		t.Run(name, func(t *testing.T) {
+			analyzer := newlineafterblock.NewWithConfig(tc.cfg)
+
+			testdata := analysistest.TestData()
+			analysistest.RunWithSuggestedFixes(t, testdata, analyzer, tc.pkg)
+		})
+	}
+}
+
+func TestNewWithConfigFlagDefaults(t *testing.T) {
+	cfg := newlineafterblock.DefaultConfig()
+	cfg.Normalize = true
+	cfg.ExcludePatterns = []string{`_test\.go$`}
+
+	analyzer := newlineafterblock.NewWithConfig(cfg)
+
+	if got := analyzer.Flags.Lookup("normalize").Value.String(); got != "true" {
+		t.Errorf("normalize = %q, want %q", got, "true")
+	}
+
+	if got := analyzer.Flags.Lookup("check-case-comments").Value.String(); got != "true" {
+		t.Errorf("check-case-comments = %q, want %q", got, "true")
+	}
+
+	if got := analyzer.Flags.Lookup("exclude").Value.String(); got != `_test\.go$` {
+		t.Errorf("exclude = %q, want %q", got, `_test\.go$`)
+	}
+}
+
+func TestNewWithConfigNegativeOptions(t *testing.T) {
+	tests := map[string]struct {
+		cfg  newlineafterblock.Config
+		flag string
+		want string
+	}{
+		"zero value case clauses":  {cfg: newlineafterblock.Config{StrictDefer: true}, flag: "case-clauses", want: "true"},
+		"zero value case comments": {cfg: newlineafterblock.Config{StrictDefer: true}, flag: "check-case-comments", want: "true"},
+		"disable case clauses":     {cfg: newlineafterblock.Config{DisableCaseClauses: true}, flag: "case-clauses", want: "false"},
+		"allow case comments":      {cfg: newlineafterblock.Config{AllowCaseComments: true}, flag: "check-case-comments", want: "false"},
+	}
+
+	for name, tc := range tests {
+		t.Run(name, func(t *testing.T) {
+			analyzer := newlineafterblock.NewWithConfig(tc.cfg)
+
+			if got := analyzer.Flags.Lookup(tc.flag).Value.String(); got != tc.want {
+				t.Errorf("%s = %q, want %q", tc.flag, got, tc.want)
+			}
+		})
+	}
+}
+
+func TestAnalyzerNormalizeAlias(t *testing.T) {
+	tests := map[string]struct {
+		args []string
+		want string
+	}{
+		"normalize":                       {args: []string{"-normalize"}, want: "1"},
+		"normalize after max-blank-lines": {args: []string{"-max-blank-lines=2", "-normalize"}, want: "1"},
+		"max-blank-lines after normalize": {args: []string{"-normalize", "-max-blank-lines=2"}, want: "2"},
+		"normalize turned off again":      {args: []string{"-normalize", "-normalize=false"}, want: "0"},
+		"normalize off keeps other value": {args: []string{"-max-blank-lines=2", "-normalize=false"}, want: "2"},
+	}
+
+	for name, tc := range tests {
+		t.Run(name, func(t *testing.T) {
+			analyzer := newlineafterblock.New()
+
+			err := analyzer.Flags.Parse(tc.args)
+			if err != nil {
+				t.Fatalf("failed to parse flags: %v", err)
+			}
+
+			if got := analyzer.Flags.Lookup("max-blank-lines").Value.String(); got != tc.want {
+				t.Errorf("max-blank-lines = %q, want %q", got, tc.want)
+			}
+		})
+	}
+}
+
+func TestNewWithConfigInvalid(t *testing.T) {
+	tests := map[string]newlineafterblock.Config{
+		"exclude pattern":      {ExcludePatterns: []string{"("}},
+		"compact pattern":      {CompactFilePatterns: []string{"("}},
+		"block kind":           {BlockKinds: []string{"while"}},
+		"min block stmts":      {MinBlockStmts: map[string]int{"if": -1}},
+		"min block stmts kind": {MinBlockStmts: map[string]int{"defer": 1}},
+	}
+
+	for name, cfg := range tests {
+		t.Run(name, func(t *testing.T) {
+			analyzer := newlineafterblock.NewWithConfig(cfg)
+
+			_, err := analyzer.Run(&analysis.Pass{Fset: token.NewFileSet()})
+			if err == nil {
+				t.Fatal("expected error for invalid configuration")
+			}
+		})
+	}
+}
+
+func TestAnalyzerMinBlockStmtsInvalidSpec(t *testing.T) {
+	tests := []string{
+		"if",
+		"while=1",
+		"if=-1",
+		"if=one",
+		"if=1,defer=1",
+	}
+
+	for _, spec := range tests {
+		t.Run(spec, func(t *testing.T) {
+			analyzer := newlineafterblock.New()
+
+			err := analyzer.Flags.Set("min-block-stmts", spec)
+			if err == nil {
+				t.Fatalf("expected error for min-block-stmts spec %q", spec)
+			}
+		})
+	}
+}
+
+func TestAnalyzerDeferGroupByComment(t *testing.T) {
+	analyzer := newlineafterblock.New()
+
+	err := analyzer.Flags.Set("defer-group-by-comment", "true")
+	if err != nil {
+		t.Fatalf("failed to set defer-group-by-comment flag: %v", err)
+	}
+
+	testdata := analysistest.TestData()
+	analysistest.Run(t, testdata, analyzer, "defergroupbycomment")
+}
+
+func TestAnalyzerDeferGroupByCommentWithFixes(t *testing.T) {
+	analyzer := newlineafterblock.New()
+
+	err := analyzer.Flags.Set("defer-group-by-comment", "true")
+	if err != nil {
+		t.Fatalf("failed to set defer-group-by-comment flag: %v", err)
+	}
+
+	testdata := analysistest.TestData()
+	analysistest.RunWithSuggestedFixes(t, testdata, analyzer, "defergroupbycomment")
+}
+
+func TestAnalyzerCaseConsistency(t *testing.T) {
+	analyzer := newlineafterblock.New()
+
+	err := analyzer.Flags.Set("case-consistency", "true")
+	if err != nil {
+		t.Fatalf("failed to set case-consistency flag: %v", err)
+	}
+
+	testdata := analysistest.TestData()
+	analysistest.Run(t, testdata, analyzer, "caseconsistency")
+}
+
+func TestAnalyzerCaseConsistencyWithFixes(t *testing.T) {
+	analyzer := newlineafterblock.New()
+
+	err := analyzer.Flags.Set("case-consistency", "true")
+	if err != nil {
+		t.Fatalf("failed to set case-consistency flag: %v", err)
+	}
+
+	testdata := analysistest.TestData()
+	analysistest.RunWithSuggestedFixes(t, testdata, analyzer, "caseconsistency")
+}
+
+func TestAnalyzerReportOncePerBlock(t *testing.T) {
+	analyzer := newlineafterblock.New()
+
+	err := analyzer.Flags.Set("report-once-per-block", "true")
+	if err != nil {
+		t.Fatalf("failed to set report-once-per-block flag: %v", err)
+	}
+
+	testdata := analysistest.TestData()
+	analysistest.Run(t, testdata, analyzer, "reportonce")
+}
+
+func TestAnalyzerReportOncePerBlockWithFixes(t *testing.T) {
+	analyzer := newlineafterblock.New()
+
+	err := analyzer.Flags.Set("report-once-per-block", "true")
+	if err != nil {
+		t.Fatalf("failed to set report-once-per-block flag: %v", err)
+	}
+
+	testdata := analysistest.TestData()
+	analysistest.RunWithSuggestedFixes(t, testdata, analyzer, "reportonce")
+}
+
+func TestAnalyzerNoExclude(t *testing.T) {
+	analyzer := newlineafterblock.New()
+
+	err := analyzer.Flags.Set("exclude", `.*_excluded\.go`)
+	if err != nil {
+		t.Fatalf("failed to set exclude flag: %v", err)
+	}
+
+	err = analyzer.Flags.Set("no-exclude", "true")
+	if err != nil {
+		t.Fatalf("failed to set no-exclude flag: %v", err)
+	}
+
+	testdata := analysistest.TestData()
+	analysistest.Run(t, testdata, analyzer, "noexclude")
+}
+
+func TestAnalyzerNoExcludeWithFixes(t *testing.T) {
+	analyzer := newlineafterblock.New()
+
+	err := analyzer.Flags.Set("exclude", `.*_excluded\.go`)
+	if err != nil {
+		t.Fatalf("failed to set exclude flag: %v", err)
+	}
+
+	err = analyzer.Flags.Set("no-exclude", "true")
+	if err != nil {
+		t.Fatalf("failed to set no-exclude flag: %v", err)
+	}
+
+	testdata := analysistest.TestData()
+	analysistest.RunWithSuggestedFixes(t, testdata, analyzer, "noexclude")
+}
+
+func TestAnalyzerGoDeferAdjacent(t *testing.T) {
+	analyzer := newlineafterblock.New()
+
+	err := analyzer.Flags.Set("allow-go-defer-adjacent", "true")
+	if err != nil {
+		t.Fatalf("failed to set allow-go-defer-adjacent flag: %v", err)
+	}
+
+	testdata := analysistest.TestData()
+	analysistest.Run(t, testdata, analyzer, "godeferadjacent")
+}
+
+func TestAnalyzerGoDeferAdjacentWithFixes(t *testing.T) {
+	analyzer := newlineafterblock.New()
+
+	err := analyzer.Flags.Set("allow-go-defer-adjacent", "true")
+	if err != nil {
+		t.Fatalf("failed to set allow-go-defer-adjacent flag: %v", err)
+	}
+
+	testdata := analysistest.TestData()
+	analysistest.RunWithSuggestedFixes(t, testdata, analyzer, "godeferadjacent")
+}
+
+func TestAnalyzerNamedFuncLitOnly(t *testing.T) {
+	analyzer := newlineafterblock.New()
+
+	err := analyzer.Flags.Set("named-funclit-only", "true")
+	if err != nil {
+		t.Fatalf("failed to set named-funclit-only flag: %v", err)
+	}
+
+	testdata := analysistest.TestData()
+	analysistest.Run(t, testdata, analyzer, "namedfunclit")
+}
+
+func TestAnalyzerNamedFuncLitOnlyWithFixes(t *testing.T) {
+	analyzer := newlineafterblock.New()
+
+	err := analyzer.Flags.Set("named-funclit-only", "true")
+	if err != nil {
+		t.Fatalf("failed to set named-funclit-only flag: %v", err)
+	}
+
+	testdata := analysistest.TestData()
+	analysistest.RunWithSuggestedFixes(t, testdata, analyzer, "namedfunclit")
+}
+
+func TestAnalyzerSameLineStatement(t *testing.T) {
+	analyzer := newlineafterblock.New()
+
+	err := analyzer.Flags.Set("flag-same-line-statement", "true")
+	if err != nil {
+		t.Fatalf("failed to set flag-same-line-statement flag: %v", err)
+	}
+
+	testdata := analysistest.TestData()
+	analysistest.Run(t, testdata, analyzer, "samelinestatement")
+}
+
+func TestAnalyzerSameLineStatementWithFixes(t *testing.T) {
+	analyzer := newlineafterblock.New()
+
+	err := analyzer.Flags.Set("flag-same-line-statement", "true")
+	if err != nil {
+		t.Fatalf("failed to set flag-same-line-statement flag: %v", err)
+	}
+
+	testdata := analysistest.TestData()
+	analysistest.RunWithSuggestedFixes(t, testdata, analyzer, "samelinestatement")
+}
+
+func TestAnalyzerCheckCaseComments(t *testing.T) {
+	analyzer := newlineafterblock.New()
+
+	err := analyzer.Flags.Set("check-case-comments", "false")
+	if err != nil {
+		t.Fatalf("failed to set check-case-comments flag: %v", err)
+	}
+
+	testdata := analysistest.TestData()
+	analysistest.Run(t, testdata, analyzer, "casecomments")
+}
+
+func TestAnalyzerCheckCaseCommentsWithFixes(t *testing.T) {
+	analyzer := newlineafterblock.New()
+
+	err := analyzer.Flags.Set("check-case-comments", "false")
+	if err != nil {
+		t.Fatalf("failed to set check-case-comments flag: %v", err)
+	}
+
+	testdata := analysistest.TestData()
+	analysistest.RunWithSuggestedFixes(t, testdata, analyzer, "casecomments")
+}
+
+func TestAnalyzerBlockKinds(t *testing.T) {
+	analyzer := newlineafterblock.New()
+
+	err := analyzer.Flags.Set("block-kinds", "if,for,switch,select,func")
+	if err != nil {
+		t.Fatalf("failed to set block-kinds flag: %v", err)
+	}
+
+	testdata := analysistest.TestData()
+	analysistest.Run(t, testdata, analyzer, "blockkinds")
+}
+
+func TestAnalyzerBlockKindsWithFixes(t *testing.T) {
+	analyzer := newlineafterblock.New()
+
+	err := analyzer.Flags.Set("block-kinds", "if,for,switch,select,func")
+	if err != nil {
+		t.Fatalf("failed to set block-kinds flag: %v", err)
+	}
+
+	testdata := analysistest.TestData()
+	analysistest.RunWithSuggestedFixes(t, testdata, analyzer, "blockkinds")
+}
+
+func TestAnalyzerBlockKindsInvalid(t *testing.T) {
+	analyzer := newlineafterblock.New()
+
+	err := analyzer.Flags.Set("block-kinds", "if,while")
+	if err == nil {
+		t.Fatal("expected error for invalid block kind")
+	}
+}
+
+func TestAnalyzerRelaxMainInit(t *testing.T) {
+	analyzer := newlineafterblock.New()
+
+	err := analyzer.Flags.Set("relax-main-init", "true")
+	if err != nil {
+		t.Fatalf("failed to set relax-main-init flag: %v", err)
+	}
+
+	testdata := analysistest.TestData()
+	analysistest.Run(t, testdata, analyzer, "relaxmaininit")
+}
+
+func TestAnalyzerRelaxMainInitWithFixes(t *testing.T) {
+	analyzer := newlineafterblock.New()
+
+	err := analyzer.Flags.Set("relax-main-init", "true")
+	if err != nil {
+		t.Fatalf("failed to set relax-main-init flag: %v", err)
+	}
+
+	testdata := analysistest.TestData()
+	analysistest.RunWithSuggestedFixes(t, testdata, analyzer, "relaxmaininit")
+}
+
+func TestAnalyzerMultilineBlocksOnly(t *testing.T) {
+	analyzer := newlineafterblock.New()
+
+	err := analyzer.Flags.Set("multiline-blocks-only", "true")
+	if err != nil {
+		t.Fatalf("failed to set multiline-blocks-only flag: %v", err)
+	}
+
+	testdata := analysistest.TestData()
+	analysistest.Run(t, testdata, analyzer, "multilineblocks")
+}
+
+func TestAnalyzerMultilineBlocksOnlyWithFixes(t *testing.T) {
+	analyzer := newlineafterblock.New()
+
+	err := analyzer.Flags.Set("multiline-blocks-only", "true")
+	if err != nil {
+		t.Fatalf("failed to set multiline-blocks-only flag: %v", err)
+	}
+
+	testdata := analysistest.TestData()
+	analysistest.RunWithSuggestedFixes(t, testdata, analyzer, "multilineblocks")
+}
+
+func TestAnalyzerRequireBefore(t *testing.T) {
+	analyzer := newlineafterblock.New()
+
+	err := analyzer.Flags.Set("require-before", "true")
+	if err != nil {
+		t.Fatalf("failed to set require-before flag: %v", err)
+	}
+
+	testdata := analysistest.TestData()
+	analysistest.Run(t, testdata, analyzer, "requirebefore")
+}
+
+func TestAnalyzerRequireBeforeWithFixes(t *testing.T) {
+	analyzer := newlineafterblock.New()
+
+	err := analyzer.Flags.Set("require-before", "true")
+	if err != nil {
+		t.Fatalf("failed to set require-before flag: %v", err)
+	}
+
+	testdata := analysistest.TestData()
+	analysistest.RunWithSuggestedFixes(t, testdata, analyzer, "requirebefore")
+}
+
+func TestAnalyzerStrictDefer(t *testing.T) {
+	analyzer := newlineafterblock.New()
+
+	err := analyzer.Flags.Set("strict-defer", "true")
+	if err != nil {
+		t.Fatalf("failed to set strict-defer flag: %v", err)
+	}
+
+	testdata := analysistest.TestData()
+	analysistest.Run(t, testdata, analyzer, "strictdefer")
+}
+
+func TestAnalyzerStrictDeferWithFixes(t *testing.T) {
+	analyzer := newlineafterblock.New()
+
+	err := analyzer.Flags.Set("strict-defer", "true")
+	if err != nil {
+		t.Fatalf("failed to set strict-defer flag: %v", err)
+	}
+
+	testdata := analysistest.TestData()
+	analysistest.RunWithSuggestedFixes(t, testdata, analyzer, "strictdefer")
+}
+
+func TestAnalyzerStrictGuardSpacing(t *testing.T) {
+	analyzer := newlineafterblock.New()
+
+	err := analyzer.Flags.Set("strict-guard-spacing", "true")
+	if err != nil {
+		t.Fatalf("failed to set strict-guard-spacing flag: %v", err)
+	}
+
+	err = analyzer.Flags.Set("min-block-stmts", "if=2")
+	if err != nil {
+		t.Fatalf("failed to set min-block-stmts flag: %v", err)
+	}
+
+	testdata := analysistest.TestData()
+	analysistest.Run(t, testdata, analyzer, "strictguardspacing")
+}
+
+func TestAnalyzerStrictGuardSpacingWithFixes(t *testing.T) {
+	analyzer := newlineafterblock.New()
+
+	err := analyzer.Flags.Set("strict-guard-spacing", "true")
+	if err != nil {
+		t.Fatalf("failed to set strict-guard-spacing flag: %v", err)
+	}
+
+	err = analyzer.Flags.Set("min-block-stmts", "if=2")
+	if err != nil {
+		t.Fatalf("failed to set min-block-stmts flag: %v", err)
+	}
+
+	testdata := analysistest.TestData()
+	analysistest.RunWithSuggestedFixes(t, testdata, analyzer, "strictguardspacing")
+}
+
+func TestAnalyzerBareBlocks(t *testing.T) {
+	analyzer := newlineafterblock.New()
+
+	err := analyzer.Flags.Set("bare-blocks", "true")
+	if err != nil {
+		t.Fatalf("failed to set bare-blocks flag: %v", err)
+	}
+
+	testdata := analysistest.TestData()
+	analysistest.Run(t, testdata, analyzer, "bareblocks")
+}
+
+func TestAnalyzerBareBlocksWithFixes(t *testing.T) {
+	analyzer := newlineafterblock.New()
+
+	err := analyzer.Flags.Set("bare-blocks", "true")
+	if err != nil {
+		t.Fatalf("failed to set bare-blocks flag: %v", err)
+	}
+
+	testdata := analysistest.TestData()
+	analysistest.RunWithSuggestedFixes(t, testdata, analyzer, "bareblocks")
+}
+
+func TestAnalyzerNoCaseClauses(t *testing.T) {
+	analyzer := newlineafterblock.New()
+
+	err := analyzer.Flags.Set("case-clauses", "false")
+	if err != nil {
+		t.Fatalf("failed to set case-clauses flag: %v", err)
+	}
+
+	testdata := analysistest.TestData()
+	analysistest.Run(t, testdata, analyzer, "nocaseclauses")
+}
+
+func TestAnalyzerNoCaseClausesWithFixes(t *testing.T) {
+	analyzer := newlineafterblock.New()
+
+	err := analyzer.Flags.Set("case-clauses", "false")
+	if err != nil {
+		t.Fatalf("failed to set case-clauses flag: %v", err)
+	}
+
+	testdata := analysistest.TestData()
+	analysistest.RunWithSuggestedFixes(t, testdata, analyzer, "nocaseclauses")
+}
+
+func TestAnalyzerExportedOnly(t *testing.T) {
+	analyzer := newlineafterblock.New()
+
+	err := analyzer.Flags.Set("exported-only", "true")
+	if err != nil {
+		t.Fatalf("failed to set exported-only flag: %v", err)
+	}
+
+	testdata := analysistest.TestData()
+	analysistest.Run(t, testdata, analyzer, "exportedonly")
+}
+
+func TestAnalyzerExportedOnlyWithFixes(t *testing.T) {
+	analyzer := newlineafterblock.New()
+
+	err := analyzer.Flags.Set("exported-only", "true")
+	if err != nil {
+		t.Fatalf("failed to set exported-only flag: %v", err)
+	}
+
+	testdata := analysistest.TestData()
+	analysistest.RunWithSuggestedFixes(t, testdata, analyzer, "exportedonly")
+}
+
+func TestAnalyzerReportAtNext(t *testing.T) {
+	analyzer := newlineafterblock.New()
+
+	err := analyzer.Flags.Set("report-at-next", "true")
+	if err != nil {
+		t.Fatalf("failed to set report-at-next flag: %v", err)
+	}
+
+	testdata := analysistest.TestData()
+	analysistest.Run(t, testdata, analyzer, "reportatnext")
+}
+
+func TestAnalyzerReportAtNextWithFixes(t *testing.T) {
+	analyzer := newlineafterblock.New()
+
+	err := analyzer.Flags.Set("report-at-next", "true")
+	if err != nil {
+		t.Fatalf("failed to set report-at-next flag: %v", err)
+	}
+
+	testdata := analysistest.TestData()
+	analysistest.RunWithSuggestedFixes(t, testdata, analyzer, "reportatnext")
+}
+
+func TestAnalyzerMessage(t *testing.T) {
+	analyzer := newlineafterblock.New()
+
+	err := analyzer.Flags.Set("message", "missing newline after {{.Kind}} block")
+	if err != nil {
+		t.Fatalf("failed to set message flag: %v", err)
+	}
+
+	testdata := analysistest.TestData()
+	analysistest.Run(t, testdata, analyzer, "message")
+}
+
+func TestAnalyzerMessageWithFixes(t *testing.T) {
+	analyzer := newlineafterblock.New()
+
+	err := analyzer.Flags.Set("message", "missing newline after {{.Kind}} block")
+	if err != nil {
+		t.Fatalf("failed to set message flag: %v", err)
+	}
+
+	testdata := analysistest.TestData()
+	analysistest.RunWithSuggestedFixes(t, testdata, analyzer, "message")
+}
+
+func TestAnalyzerMessageCaseConsistency(t *testing.T) {
+	analyzer := newlineafterblock.New()
+
+	err := analyzer.Flags.Set("message", "missing newline after {{.Kind}} block")
+	if err != nil {
+		t.Fatalf("failed to set message flag: %v", err)
+	}
+
+	err = analyzer.Flags.Set("case-consistency", "true")
+	if err != nil {
+		t.Fatalf("failed to set case-consistency flag: %v", err)
+	}
+
+	testdata := analysistest.TestData()
+	analysistest.Run(t, testdata, analyzer, "messageconsistency")
+}
+
+func TestAnalyzerMessageDetails(t *testing.T) {
+	analyzer := newlineafterblock.New()
+
+	flags := map[string]string{
+		"message":                  "missing newline after {{.Kind}} block",
+		"require-before":           "true",
+		"max-blank-lines":          "1",
+		"flag-same-line-statement": "true",
+	}
+
+	for name, value := range flags {
+		err := analyzer.Flags.Set(name, value)
+		if err != nil {
+			t.Fatalf("failed to set %s flag: %v", name, err)
+		}
+	}
+
+	testdata := analysistest.TestData()
+	analysistest.Run(t, testdata, analyzer, "messagedetails")
+}
+
+func TestAnalyzerFlagTypeDecls(t *testing.T) {
+	analyzer := newlineafterblock.New()
+
+	err := analyzer.Flags.Set("flag-type-decls", "true")
+	if err != nil {
+		t.Fatalf("failed to set flag-type-decls flag: %v", err)
+	}
+
+	testdata := analysistest.TestData()
+	analysistest.Run(t, testdata, analyzer, "typedecls")
+}
+
+func TestAnalyzerFlagTypeDeclsWithFixes(t *testing.T) {
+	analyzer := newlineafterblock.New()
+
+	err := analyzer.Flags.Set("flag-type-decls", "true")
+	if err != nil {
+		t.Fatalf("failed to set flag-type-decls flag: %v", err)
+	}
+
+	testdata := analysistest.TestData()
+	analysistest.RunWithSuggestedFixes(t, testdata, analyzer, "typedecls")
+}
+
+func TestAnalyzerSelectClauseBodies(t *testing.T) {
+	analyzer := newlineafterblock.New()
+
+	err := analyzer.Flags.Set("select-clause-bodies", "true")
+	if err != nil {
+		t.Fatalf("failed to set select-clause-bodies flag: %v", err)
+	}
+
+	testdata := analysistest.TestData()
+	analysistest.Run(t, testdata, analyzer, "selectclausebodies")
+}
+
+func TestAnalyzerSelectClauseBodiesWithFixes(t *testing.T) {
+	analyzer := newlineafterblock.New()
+
+	err := analyzer.Flags.Set("select-clause-bodies", "true")
+	if err != nil {
+		t.Fatalf("failed to set select-clause-bodies flag: %v", err)
+	}
+
+	testdata := analysistest.TestData()
+	analysistest.RunWithSuggestedFixes(t, testdata, analyzer, "selectclausebodies")
+}
+
+func TestAnalyzerMaxBlankLines(t *testing.T) {
+	analyzer := newlineafterblock.New()
+
+	err := analyzer.Flags.Set("max-blank-lines", "2")
+	if err != nil {
+		t.Fatalf("failed to set max-blank-lines flag: %v", err)
+	}
+
+	testdata := analysistest.TestData()
+	analysistest.Run(t, testdata, analyzer, "maxblanklines")
+}
+
+func TestAnalyzerMaxBlankLinesWithFixes(t *testing.T) {
+	analyzer := newlineafterblock.New()
+
+	err := analyzer.Flags.Set("max-blank-lines", "2")
+	if err != nil {
+		t.Fatalf("failed to set max-blank-lines flag: %v", err)
+	}
+
+	testdata := analysistest.TestData()
+	analysistest.RunWithSuggestedFixes(t, testdata, analyzer, "maxblanklines")
+}
+
+func TestAnalyzerMessageInvalid(t *testing.T) {
+	tests := []string{
+		"missing newline after {{.Kind block",
+		"missing newline after {{.Name}} block",
+	}
+
+	for _, message := range tests {
+		t.Run(message, func(t *testing.T) {
+			analyzer := newlineafterblock.New()
+
+			err := analyzer.Flags.Set("message", message)
+			if err == nil {
+				t.Fatalf("expected error for message template %q", message)
+			}
+		})
+	}
+}
@@ -0,0 +1,44 @@
+package newlineafterblock
+
+import "strconv"
+
+// normalizeFlag is a custom boolean flag type for -normalize, which is an alias
+// of -max-blank-lines=1. It shares the value of -max-blank-lines, so the flag
+// given last takes precedence.
+type normalizeFlag struct {
+	maxBlankLines *int
+}
+
+// IsBoolFlag allows the flag to be given without a value.
+func (f normalizeFlag) IsBoolFlag() bool {
+	return true
+}
+
+// String returns "true" if exactly one blank line is allowed after blocks.
+func (f normalizeFlag) String() string {
+	if f.maxBlankLines == nil {
+		return "false"
+	}
+
+	return strconv.FormatBool(*f.maxBlankLines == 1)
+}
+
+// Set allows exactly one blank line after blocks if value is true. If value is
+// false, the check is disabled again unless -max-blank-lines set a different
+// number of blank lines.
+func (f normalizeFlag) Set(value string) error {
+	normalize, err := strconv.ParseBool(value)
+	if err != nil {
+		return err
+	}
+
+	switch {
+	case normalize:
+		*f.maxBlankLines = 1
+
+	case *f.maxBlankLines == 1:
+		*f.maxBlankLines = 0
+	}
+
+	return nil
+}
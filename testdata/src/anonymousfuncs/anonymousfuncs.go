@@ -137,3 +137,30 @@ func shortVarDeclarationWithStatement() {
 	} // want "missing newline after block statement"
 	fmt.Println(f)
 }
+
+type builder struct{}
+
+func (b *builder) Method(f func()) *builder {
+	f()
+	return b
+}
+
+func (b *builder) Next() *builder {
+	return b
+}
+
+func methodChainWithClosureAndContinuation(b *builder) {
+	result := b.Method(func() {
+		fmt.Println("inside")
+	}).
+		Next()
+	fmt.Println(result)
+}
+
+func methodChainStatementWithClosureAndContinuation(b *builder) {
+	b.Method(func() {
+		fmt.Println("inside")
+	}).
+		Next()
+	fmt.Println("next statement")
+}
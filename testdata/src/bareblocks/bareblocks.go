@@ -0,0 +1,75 @@
+package bareblocks
+
+import "fmt"
+
+// Scoping block followed by a statement - violation with -bare-blocks
+func scopingBlockFollowedByStatement() {
+	{
+		x := 1
+		fmt.Println(x)
+	} // want "missing newline after block statement"
+	fmt.Println("next statement")
+}
+
+// Scoping block followed by a blank line - correct
+func scopingBlockWithBlankLine() {
+	{
+		x := 1
+		fmt.Println(x)
+	}
+
+	fmt.Println("next statement")
+}
+
+// Scoping block at the end of the function - correct
+func scopingBlockAtFunctionEnd() {
+	fmt.Println("first statement")
+	{
+		x := 1
+		fmt.Println(x)
+	}
+}
+
+// Nested blocks are reported once each, the inner statements are checked as usual
+func nestedScopingBlocks() {
+	{
+		{
+			fmt.Println("inner")
+		} // want "missing newline after block statement"
+		if true {
+			fmt.Println("if")
+		} // want "missing newline after block statement"
+		fmt.Println("outer")
+	} // want "missing newline after block statement"
+	fmt.Println("next statement")
+}
+
+// Consecutive scoping blocks - violation
+func consecutiveScopingBlocks() {
+	{
+		fmt.Println("first")
+	} // want "missing newline after block statement"
+	{
+		fmt.Println("second")
+	}
+}
+
+// Scoping block in a case clause - violation
+func scopingBlockInCase(x int) {
+	switch x {
+	case 1:
+		{
+			fmt.Println("one")
+		} // want "missing newline after block statement"
+		fmt.Println("still one")
+	}
+}
+
+// Function body is not a bare block - no violation
+func functionLiteralBody() {
+	f := func() {
+		fmt.Println("body")
+	}
+
+	f()
+}
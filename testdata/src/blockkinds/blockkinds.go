@@ -0,0 +1,51 @@
+package blockkinds
+
+import "fmt"
+
+// Test cases for the -block-kinds flag, configured as if,for,switch,select,func (range disabled)
+
+func forLoopIsFlagged() {
+	for i := 0; i < 3; i++ {
+		fmt.Println(i)
+	} // want "missing newline after block statement"
+	fmt.Println("next statement")
+}
+
+func rangeLoopIsNotFlagged(items []int) {
+	for _, item := range items {
+		fmt.Println(item)
+	}
+	fmt.Println("next statement")
+}
+
+func rangeLoopWithTrailingCommentIsNotFlagged(items []int) {
+	if len(items) > 0 {
+		for _, item := range items {
+			fmt.Println(item)
+		}
+		// Comment directly after the range loop
+	}
+}
+
+func ifIsFlagged() {
+	x := 5
+	if x > 0 {
+		fmt.Println("positive")
+	} // want "missing newline after block statement"
+	fmt.Println("next statement")
+}
+
+func rangeLoopInsideForLoop(items [][]int) {
+	for i := 0; i < len(items); i++ {
+		for _, item := range items[i] {
+			fmt.Println(item)
+		}
+		fmt.Println("row done")
+	} // want "missing newline after block statement"
+	fmt.Println("next statement")
+}
+
+func deferIsNotABlockKind() {
+	defer fmt.Println("cleanup") // want "missing newline after block statement"
+	fmt.Println("next statement")
+}
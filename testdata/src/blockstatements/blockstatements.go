@@ -317,3 +317,209 @@ func typeSwitchWithNewline() {
 
 	fmt.Println("after type switch")
 }
+
+func ifBodyEndingWithLoop() {
+	x := 5
+	if x > 0 {
+		for i := 0; i < x; i++ {
+			fmt.Println(i)
+		}
+	} // want "missing newline after block statement"
+	fmt.Println("next statement")
+}
+
+func switchCaseEndingWithBlock(x int) {
+	switch x {
+	case 1:
+		if x > 0 {
+			fmt.Println("positive")
+		}
+	} // want "missing newline after block statement"
+	fmt.Println("next statement")
+}
+
+func nestedBlocksClosingTogether(items [][]int) {
+	for _, row := range items {
+		for _, item := range row {
+			if item > 0 {
+				fmt.Println(item)
+			}
+		}
+	} // want "missing newline after block statement"
+	fmt.Println("next statement")
+}
+
+func nestedBlockFollowedByStatementInOuterBlock(items [][]int) {
+	for _, row := range items {
+		for _, item := range row {
+			if item > 0 {
+				fmt.Println(item)
+			}
+		} // want "missing newline after block statement"
+		fmt.Println("row done")
+	}
+}
+
+func loopWithLabeledLastStatement() {
+	x := 0
+	for i := 0; i < 3; i++ {
+		if i == 1 {
+			goto Done
+		}
+
+		x++
+	Done:
+		x--
+	} // want "missing newline after block statement"
+	fmt.Println(x)
+}
+
+func loopWithLabeledLoopAsLastStatement(items [][]int) {
+	for _, row := range items {
+	Inner:
+		for _, item := range row {
+			if item < 0 {
+				break Inner
+			}
+		}
+	} // want "missing newline after block statement"
+	fmt.Println("next statement")
+}
+
+func deferAfterErrorTypeFromExcludedFile() {
+	res, err := acquire()
+	if err != nil {
+		return
+	}
+	defer res.release()
+
+	fmt.Println("working")
+}
+
+func infiniteLoopWithBreakFollowedByStatement() {
+	x := 0
+	for {
+		x++
+		if x > 3 {
+			break
+		}
+	} // want "missing newline after block statement"
+	fmt.Println(x)
+}
+
+func infiniteLoopWithBreakFollowedByBlankLine() {
+	x := 0
+	for {
+		x++
+		if x > 3 {
+			break
+		}
+	}
+
+	fmt.Println(x)
+}
+
+func blockFollowedByEmptySwitch() {
+	x := 5
+	if x > 0 {
+		fmt.Println("positive")
+	} // want "missing newline after block statement"
+	switch {
+	}
+}
+
+func blockFollowedByEmptySelect() {
+	x := 5
+	if x > 0 {
+		fmt.Println("positive")
+	} // want "missing newline after block statement"
+	select {}
+}
+
+func blockFollowedByIfWithInit() {
+	x := 5
+	if x > 0 {
+		fmt.Println("positive")
+	} // want "missing newline after block statement"
+	if y := x * 2; y > 5 {
+		fmt.Println(y)
+	}
+}
+
+func labeledForFollowedByStatement(items []int) {
+Loop:
+	for _, item := range items {
+		if item < 0 {
+			break Loop
+		}
+	} // want "missing newline after block statement"
+	fmt.Println("next statement")
+}
+
+func labeledForWithBlankLine(items []int) {
+Loop:
+	for _, item := range items {
+		if item < 0 {
+			break Loop
+		}
+	}
+
+	fmt.Println("next statement")
+}
+
+func labeledSwitchFollowedByStatement(x int) {
+Switch:
+	switch x {
+	case 1:
+		break Switch
+	} // want "missing newline after block statement"
+	fmt.Println("next statement")
+}
+
+func labeledSelectFollowedByStatement(ch chan int) {
+Select:
+	select {
+	case v := <-ch:
+		if v < 0 {
+			break Select
+		}
+	} // want "missing newline after block statement"
+	fmt.Println("next statement")
+}
+
+func singleBlockFunctionOnOneLine(x int) { if x > 0 { fmt.Println("positive") } }
+
+func singleBlockFunctionHuggingBraces(x int) {
+	for i := 0; i < x; i++ {
+		fmt.Println(i)
+	}
+}
+
+func singleBlockFunctionWithTrailingInlineComment(x int) {
+	if x > 0 {
+		fmt.Println("positive")
+	} // only statement
+}
+
+func ifElseContainingLoopFollowedByStatement(x int) {
+	if x > 0 {
+		fmt.Println("positive")
+	} else {
+		for i := 0; i < 3; i++ {
+			fmt.Println(i)
+		}
+	} // want "missing newline after block statement"
+	fmt.Println("next statement")
+}
+
+func ifElseEndingWithLoopFollowedByStatement(x int) {
+	if x > 0 {
+		fmt.Println("positive")
+	} else {
+		fmt.Println("not positive")
+		for {
+			break
+		}
+	} // want "missing newline after block statement"
+	fmt.Println("next statement")
+}
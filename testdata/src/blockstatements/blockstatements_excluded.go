@@ -74,3 +74,20 @@ func nestedViolations() {
 	} // no "missing newline after block statement" report, since the file is excluded.
 	fmt.Println("after outer if")
 }
+
+// excludedError is used in blockstatements.go, error detection relies on the
+// package wide type information and therefore also works for types declared
+// in excluded files.
+type excludedError struct{}
+
+func (e *excludedError) Error() string {
+	return "excluded error"
+}
+
+type resource struct{}
+
+func (r *resource) release() {}
+
+func acquire() (*resource, *excludedError) {
+	return &resource{}, nil
+}
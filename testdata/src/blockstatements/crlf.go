@@ -0,0 +1,12 @@
+package blockstatements
+
+import "fmt"
+
+// This file uses CRLF line endings, fixes insert CRLF line endings as well.
+
+func crlfBlockFollowedByStatement() {
+	for i := 0; i < 3; i++ {
+		fmt.Println(i)
+	} // want "missing newline after block statement"
+	fmt.Println("next statement")
+}
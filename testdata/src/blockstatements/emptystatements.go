@@ -0,0 +1,84 @@
+package blockstatements
+
+import "fmt"
+
+// This file contains empty statements (stray semicolons), which gofmt removes.
+// Empty statements are skipped, so a block is compared with the next real statement.
+// A line holding only an empty statement is not a blank line.
+
+func blockFollowedBySemicolonsOnSameLine() {
+	x := 5
+	if x > 0 {
+		fmt.Println("positive")
+	};; // want "missing newline after block statement"
+	fmt.Println("next statement")
+}
+
+func blockFollowedBySemicolonOnOwnLine() {
+	x := 5
+	if x > 0 {
+		fmt.Println("positive")
+	} // want "missing newline after block statement"
+	;
+	fmt.Println("next statement")
+}
+
+func blockFollowedBySemicolonsOnOwnLines() {
+	x := 5
+	if x > 0 {
+		fmt.Println("positive")
+	} // want "missing newline after block statement"
+	;
+	;
+	fmt.Println("next statement")
+}
+
+func blockFollowedBySemicolonAndBlankLine() {
+	x := 5
+	if x > 0 {
+		fmt.Println("positive")
+	}
+	;
+
+	fmt.Println("next statement")
+}
+
+func blockFollowedBySemicolonAtEndOfFunction() {
+	x := 5
+	if x > 0 {
+		fmt.Println("positive")
+	};;
+}
+
+func semicolonFollowedByStatement() {
+	x := 5;;
+	fmt.Println(x)
+}
+
+// A single semicolon after the brace only terminates the statement, it does
+// not produce an empty statement.
+
+func blockWithTerminatingSemicolonFollowedByStatement() {
+	x := 5
+	if x > 0 {
+		fmt.Println("positive")
+	}; // want "missing newline after block statement"
+	fmt.Println("next statement")
+}
+
+func blockWithTerminatingSemicolonAndBlankLine() {
+	for i := 0; i < 3; i++ {
+		fmt.Println(i)
+	};
+
+	fmt.Println("next statement")
+}
+
+func blockWithTerminatingSemicolonFollowedByComment() {
+	x := 5
+	if x > 0 {
+		fmt.Println("positive")
+	}; // want "missing newline after block statement"
+	// Comment directly after the block
+	fmt.Println("next statement")
+}
@@ -268,3 +268,209 @@ func switchWithBlocksInside() {
 		fmt.Println("case 2") // No blank line needed before }
 	}
 }
+
+// Comment directly after the opening brace, before the first case (no report)
+func switchWithCommentBeforeFirstCase() {
+	x := 1
+	switch x {
+	// Comment right after the opening brace does not need a blank line above
+	case 1:
+		fmt.Println("one")
+
+	default:
+		fmt.Println("other")
+	}
+}
+
+// Comment directly after the opening brace of a select, before the first case (no report)
+func selectWithCommentBeforeFirstCase() {
+	ch := make(chan int)
+	select {
+	// Comment right after the opening brace does not need a blank line above
+	case v := <-ch:
+		fmt.Println(v)
+
+	default:
+		fmt.Println("no value")
+	}
+}
+
+// Type switch case ending with a block followed by another case
+func typeSwitchCaseEndingWithBlock(a any) {
+	switch v := a.(type) {
+	case string:
+		if v != "" {
+			fmt.Println("string:", v)
+		} // want "missing newline after case block"
+	case int:
+		for i := 0; i < v; i++ {
+			fmt.Println(i)
+		} // want "missing newline after case block"
+	default:
+		fmt.Println("unknown type")
+	}
+}
+
+// Select with only a default clause followed by a statement
+func selectOnlyDefaultFollowedByStatement() {
+	select {
+	default:
+		fmt.Println("nothing ready")
+	} // want "missing newline after block statement"
+	fmt.Println("next statement")
+}
+
+// Select with only a default clause at the end of the function
+func selectOnlyDefaultAtFunctionEnd() {
+	select {
+	default:
+		fmt.Println("nothing ready")
+	}
+}
+
+// Select nested in a switch case, both levels are checked independently
+func selectNestedInSwitchCase(x int, ch1, ch2 chan int) {
+	switch x {
+	case 1:
+		select {
+		case v := <-ch1:
+			fmt.Println(v) // want "missing newline after case block"
+		case v := <-ch2:
+			fmt.Println(v)
+		} // want "missing newline after case block"
+	case 2:
+		fmt.Println("x=2")
+	}
+}
+
+// Select nested in a switch case - correct
+func selectNestedInSwitchCaseWithNewlines(x int, ch1, ch2 chan int) {
+	switch x {
+	case 1:
+		select {
+		case v := <-ch1:
+			fmt.Println(v)
+
+		case v := <-ch2:
+			fmt.Println(v)
+		}
+
+	case 2:
+		fmt.Println("x=2")
+	}
+}
+
+// Default clause first, followed by a case without a blank line
+func defaultClauseFirst(x int) {
+	switch x {
+	default:
+		fmt.Println("other") // want "missing newline after case block"
+	case 1:
+		fmt.Println("one") // want "missing newline after case block"
+	case 2:
+		fmt.Println("two")
+	}
+}
+
+// Default clause first - correct
+func defaultClauseFirstWithNewlines(x int) {
+	switch x {
+	default:
+		fmt.Println("other")
+
+	case 1:
+		fmt.Println("one")
+	}
+}
+
+// Empty cases mixed with non-empty cases in various orders
+func switchEmptyCasesMixed(x int) {
+	switch x {
+	case 1:
+	case 2:
+		fmt.Println("two") // want "missing newline after case block"
+	case 3:
+		fmt.Println("three") // want "missing newline after case block"
+	case 4:
+	case 5:
+	case 6:
+		fmt.Println("six")
+	}
+}
+
+// Non-empty case followed by an empty case still needs a blank line
+func switchNonEmptyCaseBeforeEmptyCase(x int) {
+	switch x {
+	case 1:
+		fmt.Println("one") // want "missing newline after case block"
+	case 2:
+	default:
+		fmt.Println("other")
+	}
+}
+
+// Empty last case
+func switchEmptyLastCase(x int) {
+	switch x {
+	case 1:
+		fmt.Println("one")
+
+	case 2:
+	}
+}
+
+// Block, switch and statement chained without blank lines, the after-block
+// checks of both blocks and the case clause check of the switch are independent
+func blockSwitchStatementChain(x int) {
+	if x > 0 {
+		fmt.Println("positive")
+	} // want "missing newline after block statement"
+	switch x {
+	case 1:
+		fmt.Println("one") // want "missing newline after case block"
+	case 2:
+		fmt.Println("two")
+	} // want "missing newline after block statement"
+	fmt.Println("done")
+}
+
+// Block, select and statement chained without blank lines
+func blockSelectStatementChain(ch1, ch2 chan int) {
+	for i := 0; i < 2; i++ {
+		fmt.Println(i)
+	} // want "missing newline after block statement"
+	select {
+	case v := <-ch1:
+		fmt.Println(v) // want "missing newline after case block"
+	case v := <-ch2:
+		fmt.Println(v)
+	} // want "missing newline after block statement"
+	fmt.Println("done")
+}
+
+// Block ending the last case followed by a comment before the closing brace
+func switchLastCaseBlockFollowedByComment(x int) {
+	switch x {
+	case 1:
+		fmt.Println("one")
+
+	default:
+		if x > 0 {
+			fmt.Println("positive")
+		} // want "missing newline after block statement"
+		// Trailing comment of the last case
+	}
+}
+
+// Select clause bodies are only checked with -select-clause-bodies - no violation
+func selectCaseBlockFollowedByStatementUnchecked(ch chan int) {
+	select {
+	case v := <-ch:
+		if v > 0 {
+			fmt.Println("positive")
+		}
+		fmt.Println(v)
+
+	default:
+	}
+}
@@ -0,0 +1,59 @@
+package casecomments
+
+import "fmt"
+
+// Test cases for the -check-case-comments=false flag
+
+func commentHuggingCaseBody(x int) {
+	switch x {
+	case 1:
+		fmt.Println("one")
+	// Comment directly after the case body
+	case 2:
+		fmt.Println("two")
+	}
+}
+
+func commentHuggingCaseBodyAndNextCase(x int) {
+	switch x {
+	case 1:
+		fmt.Println("one")
+	// Comment between the cases
+	case 2:
+		fmt.Println("two") // want "missing newline after case block"
+	case 3:
+		fmt.Println("three")
+	}
+}
+
+func commentHuggingSelectCaseBody(ch1, ch2 chan int) {
+	select {
+	case v := <-ch1:
+		fmt.Println(v)
+	// Comment directly after the case body
+	case v := <-ch2:
+		fmt.Println(v)
+	}
+}
+
+func commentInsideCaseBody(x int) {
+	switch x {
+	case 1:
+		fmt.Println("one")
+		// Comment belonging to the case body
+	case 2:
+		fmt.Println("two")
+	}
+}
+
+func blockEndingCaseFollowedByComment(x int) {
+	switch x {
+	case 1:
+		if x > 0 {
+			fmt.Println("positive")
+		} // want "missing newline after block statement"
+	// The block rule still applies to the block ending the case body
+	case 2:
+		fmt.Println("two")
+	}
+}
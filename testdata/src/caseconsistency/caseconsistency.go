@@ -0,0 +1,115 @@
+package caseconsistency
+
+import "fmt"
+
+// Test cases for the -case-consistency flag
+
+func allCasesSeparated(x int) {
+	switch x {
+	case 1:
+		fmt.Println("one")
+
+	case 2:
+		fmt.Println("two")
+
+	default:
+		fmt.Println("other")
+	}
+}
+
+func noCasesSeparated(x int) {
+	switch x {
+	case 1:
+		fmt.Println("one")
+	case 2:
+		fmt.Println("two")
+	default:
+		fmt.Println("other")
+	}
+}
+
+func inconsistentSwitch(x int) {
+	switch x {
+	case 1:
+		fmt.Println("one")
+
+	case 2:
+		fmt.Println("two") // want "inconsistent blank lines between case blocks"
+	case 3:
+		fmt.Println("three")
+
+	default:
+		fmt.Println("other")
+	}
+}
+
+func inconsistentSwitchWithComment(x int) {
+	switch x {
+	case 1:
+		fmt.Println("one") // want "inconsistent blank lines between case blocks"
+	// Comment directly before the case counts as missing blank line
+	case 2:
+		fmt.Println("two")
+
+	default:
+		fmt.Println("other")
+	}
+}
+
+func emptyCasesAreIgnored(x int) {
+	switch x {
+	case 1:
+	case 2:
+		fmt.Println("two")
+	case 3:
+		fmt.Println("three")
+	}
+}
+
+func inconsistentTypeSwitch(v any) {
+	switch v.(type) {
+	case int:
+		fmt.Println("int") // want "inconsistent blank lines between case blocks"
+	case string:
+		fmt.Println("string")
+
+	default:
+		fmt.Println("other")
+	}
+}
+
+func consistencyIsPerSwitch(x, y int) {
+	switch x {
+	case 1:
+		switch y {
+		case 1:
+			fmt.Println("one")
+		case 2:
+			fmt.Println("two")
+		}
+
+	default:
+		fmt.Println("other")
+	}
+}
+
+func noSelectCasesSeparated(ch1, ch2 chan int) {
+	select {
+	case v := <-ch1:
+		fmt.Println(v)
+	case v := <-ch2:
+		fmt.Println(v)
+	}
+}
+
+func inconsistentSelect(ch1, ch2 chan int) {
+	select {
+	case v := <-ch1:
+		fmt.Println(v)
+
+	case v := <-ch2:
+		fmt.Println(v) // want "inconsistent blank lines between case blocks"
+	default:
+		fmt.Println("nothing ready")
+	}
+}
@@ -307,3 +307,117 @@ func blockWithInlineCommentThenBlockCommentCorrect() {
 	// Block comment with proper spacing
 	fmt.Println("next")
 }
+
+func blockWithHuggingCommentThenBlankLineThenStatement() {
+	x := 5
+	if x > 0 {
+		fmt.Println("positive")
+	} // want "missing newline after block statement"
+	// Only the comment hugs the block, the statement below is properly spaced
+
+	fmt.Println("next")
+}
+
+func singleNestedBlockWithTrailingComment() {
+	x := 5
+	if x > 0 {
+		for i := 0; i < x; i++ {
+			fmt.Println(i)
+		} // want "missing newline after block statement"
+		// The nested loop is the only statement in the if body
+	}
+}
+
+func blockWithInlineNoteFollowedByStatement() {
+	x := 5
+	if x > 0 {
+		fmt.Println("positive")
+	} // note on the brace line does not count as separation // want "missing newline after block statement"
+	fmt.Println("next statement")
+}
+
+func blockWithGluedInlineCommentFollowedByStatement() {
+	x := 5
+	if x > 0 {
+		fmt.Println("positive")
+	}/* glued to the brace */ // want "missing newline after block statement"
+	fmt.Println("next statement")
+}
+
+func blockWithGluedInlineCommentThenComment() {
+	x := 5
+	if x > 0 {
+		fmt.Println("positive")
+	}/* glued to the brace */ // want "missing newline after block statement"
+	// Comment directly below
+	fmt.Println("next statement")
+}
+
+func blockWithGluedInlineCommentAndBlankLine() {
+	x := 5
+	if x > 0 {
+		fmt.Println("positive")
+	}/* glued to the brace */
+
+	fmt.Println("next statement")
+}
+
+func blockFollowedByBlockWithLeadingComment() {
+	x := 5
+	if x > 0 {
+		fmt.Println("positive")
+	}
+
+	for i := 0; i < x; i++ {
+		// Leading comment inside the following block
+		fmt.Println(i)
+	}
+}
+
+func blockFollowedDirectlyByBlockWithLeadingComment() {
+	x := 5
+	if x > 0 {
+		fmt.Println("positive")
+	} // want "missing newline after block statement"
+	for i := 0; i < x; i++ {
+		// Leading comment inside the following block
+		fmt.Println(i)
+	}
+}
+
+func nestedBlockFollowedBySiblingBlockWithLeadingComment() {
+	x := 5
+	if x > 0 {
+		for i := 0; i < x; i++ {
+			fmt.Println(i)
+		}
+	}
+
+	if x > 1 {
+		// Leading comment inside the sibling block of the enclosing block
+		fmt.Println("greater than one")
+	}
+}
+
+func blockFollowedByBlankLineThenInlineCommentedStatement() {
+	x := 5
+	if x > 0 {
+		fmt.Println("positive")
+	}
+
+	y := 5 // inline note
+	fmt.Println(y)
+}
+
+func blockWithInlineCommentThenBlankLineThenSeveralBlockComments() {
+	x := 5
+	if x > 0 {
+		fmt.Println("positive")
+	} // inline comment
+
+	// Block comment after the blank line,
+	// spanning two lines
+
+	// Another block comment directly above the statement
+	fmt.Println("next") // inline comment on the statement
+}
@@ -0,0 +1,12 @@
+package comments
+
+import "fmt"
+
+// This file ends with a comment directly after a block, without a trailing newline.
+
+func blockFollowedByCommentAtEndOfFile(x int) {
+	if x > 0 {
+		fmt.Println("positive")
+	} // want "missing newline after block statement"
+	// trailing comment
+}
\ No newline at end of file
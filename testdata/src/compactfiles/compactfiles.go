@@ -0,0 +1,13 @@
+package compactfiles
+
+import "fmt"
+
+// This file does not match the compact file pattern and is fully analyzed.
+
+func ifStatementWithoutNewline() {
+	x := 5
+	if x > 0 {
+		fmt.Println("positive")
+	} // want "missing newline after block statement"
+	fmt.Println("next statement")
+}
@@ -0,0 +1,35 @@
+package compactfiles
+
+import "fmt"
+
+// This file matches the compact file pattern, so missing blank lines after
+// blocks are not reported. Surplus blank lines (-normalize) still are.
+
+func compactIfStatement() {
+	x := 5
+	if x > 0 {
+		fmt.Println("positive")
+	}
+	fmt.Println("next statement")
+}
+
+func compactSwitchStatement(x int) {
+	switch x {
+	case 1:
+		fmt.Println("one")
+	default:
+		fmt.Println("other")
+	}
+	fmt.Println("after switch")
+}
+
+func compactExcessBlankLines() {
+	x := 5
+	if x > 0 {
+		fmt.Println("positive")
+	} // want "too many blank lines after block statement"
+
+
+
+	fmt.Println("next statement")
+}
@@ -0,0 +1,83 @@
+package deferanyguard
+
+import (
+	"fmt"
+	"sync"
+)
+
+// Test cases for the -defer-exception-any-guard flag
+
+func guardWithReturnFollowedByDefer(mu *sync.Mutex, ok bool) {
+	if !ok {
+		return
+	}
+	defer mu.Unlock()
+
+	fmt.Println("locked")
+}
+
+func guardWithPanicFollowedByDefer(mu *sync.Mutex) {
+	if mu == nil {
+		panic("no mutex")
+	}
+	defer mu.Unlock()
+
+	fmt.Println("locked")
+}
+
+func guardWithContinueFollowedByDefer(items []int) {
+	for _, item := range items {
+		if item < 0 {
+			continue
+		}
+		defer fmt.Println("cleanup", item)
+
+		fmt.Println(item)
+	}
+}
+
+func errorCheckFollowedByDefer(err error) error {
+	if err != nil {
+		return err
+	}
+	defer fmt.Println("cleanup")
+
+	return nil
+}
+
+func nonTerminatingIfFollowedByDefer(x int) {
+	if x > 0 {
+		fmt.Println("positive")
+	} // want "missing newline after block statement"
+	defer fmt.Println("cleanup")
+
+	fmt.Println("done")
+}
+
+func multiStatementGuardFollowedByDefer(x int) {
+	if x < 0 {
+		fmt.Println("negative")
+		return
+	} // want "missing newline after block statement"
+	defer fmt.Println("cleanup")
+
+	fmt.Println("done")
+}
+
+func guardWithElseFollowedByDefer(x int) {
+	if x < 0 {
+		return
+	} else {
+		fmt.Println("not negative")
+	} // want "missing newline after block statement"
+	defer fmt.Println("cleanup")
+
+	fmt.Println("done")
+}
+
+func guardFollowedByStatement(x int) {
+	if x < 0 {
+		return
+	} // want "missing newline after block statement"
+	fmt.Println("done")
+}
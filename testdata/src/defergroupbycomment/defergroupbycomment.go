@@ -0,0 +1,52 @@
+package defergroupbycomment
+
+import (
+	"fmt"
+	"os"
+)
+
+// Test cases for the -defer-group-by-comment flag
+
+func consecutiveDefersWithoutComment() {
+	defer fmt.Println("first")
+	defer fmt.Println("second")
+
+	fmt.Println("work")
+}
+
+func commentStartsNewGroup() {
+	f, err := os.Open("example.txt")
+	if err != nil {
+		return
+	}
+	defer f.Close() // want "missing newline after block statement"
+	// Logging
+	defer fmt.Println("done")
+	defer fmt.Println("exiting")
+
+	fmt.Println("work")
+}
+
+func commentedGroupsSeparatedByBlankLine() {
+	// Cleanup
+	defer fmt.Println("cleanup")
+	defer fmt.Println("more cleanup")
+
+	// Logging
+	defer fmt.Println("done")
+
+	fmt.Println("work")
+}
+
+func inlineCommentKeepsGroup() {
+	defer fmt.Println("first") // first cleanup
+	defer fmt.Println("second")
+
+	fmt.Println("work")
+}
+
+func commentWithinGroupAtFunctionEnd() {
+	defer fmt.Println("first") // want "missing newline after block statement"
+	// Second group
+	defer fmt.Println("second")
+}
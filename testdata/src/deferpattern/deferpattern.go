@@ -241,3 +241,267 @@ func reversedOperandsWithDifferentName() error {
 	fmt.Println("processing file")
 	return nil
 }
+
+// Test 20: Struct field error check followed by defer (should NOT warn - type-based detection)
+type conn struct {
+	err error
+}
+
+func structFieldErrorFollowedByDefer(c *conn) error {
+	if c.err != nil {
+		return c.err
+	}
+	defer fmt.Println("cleanup")
+
+	fmt.Println("connected")
+	return nil
+}
+
+// Test 21: Defer inside a deferred closure followed by a statement (SHOULD warn)
+func nestedDeferInsideDeferredClosure() {
+	defer func() {
+		defer fmt.Println("inner cleanup") // want "missing newline after block statement"
+		fmt.Println("work")
+	}()
+
+	fmt.Println("body")
+}
+
+// Test 22: Defer inside a deeply nested closure followed by a statement (SHOULD warn)
+func deeplyNestedDeferInsideClosures() {
+	defer func() {
+		run := func() {
+			defer fmt.Println("innermost cleanup") // want "missing newline after block statement"
+			fmt.Println("work")
+		}
+
+		run()
+	}()
+
+	fmt.Println("body")
+}
+
+// Test 23: Error check followed by a comment and a defer (SHOULD warn - comment breaks the exception)
+func errorCheckFollowedByCommentAndDefer() error {
+	file, err := os.Open("example.txt")
+	if err != nil {
+		return err
+	} // want "missing newline after block statement"
+	// Close the file when done
+	defer file.Close()
+
+	fmt.Println("processing file")
+	return nil
+}
+
+// Test 24: Error check followed by a blank line, a comment and a defer (should NOT warn)
+func errorCheckFollowedByBlankLineCommentAndDefer() error {
+	file, err := os.Open("example.txt")
+	if err != nil {
+		return err
+	}
+
+	// Close the file when done
+	defer file.Close()
+
+	fmt.Println("processing file")
+	return nil
+}
+
+// Test 25: Error check with inline comment followed by a defer (should NOT warn)
+func errorCheckWithInlineCommentFollowedByDefer() error {
+	file, err := os.Open("example.txt")
+	if err != nil {
+		return err
+	} // inline comment
+	defer file.Close()
+
+	fmt.Println("processing file")
+	return nil
+}
+
+// Test 26: Non-error-check if with init statement followed by defer (SHOULD warn)
+func nonErrorCheckIfWithInitFollowedByDefer() {
+	if x := 5; x > 0 {
+		fmt.Println("positive")
+	} // want "missing newline after block statement"
+	defer fmt.Println("cleanup")
+
+	fmt.Println("done")
+}
+
+// Test 27: Loop followed by defer (SHOULD warn)
+func loopFollowedByDefer() {
+	for i := 0; i < 3; i++ {
+		fmt.Println(i)
+	} // want "missing newline after block statement"
+	defer fmt.Println("cleanup")
+
+	fmt.Println("done")
+}
+
+// Test 28: Parenthesized error operand followed by defer (should NOT warn)
+func parenthesizedErrorCheckFollowedByDefer() error {
+	file, err := os.Open("example.txt")
+	if (err) != nil {
+		return err
+	}
+	defer file.Close()
+
+	fmt.Println("processing file")
+	return nil
+}
+
+// Test 29: Parenthesized nil and reversed operands followed by defer (should NOT warn)
+func parenthesizedNilFollowedByDefer() error {
+	file, err := os.Open("example.txt")
+	if (nil) != (err) {
+		return err
+	}
+	defer file.Close()
+
+	fmt.Println("processing file")
+	return nil
+}
+
+// Test 30: Parenthesized error check condition followed by defer (should NOT warn)
+func parenthesizedConditionFollowedByDefer() error {
+	file, err := os.Open("example.txt")
+	if (err != nil) {
+		return err
+	}
+	defer file.Close()
+
+	fmt.Println("processing file")
+	return nil
+}
+
+// Test 31: Type alias of error followed by defer (should NOT warn - type-based detection)
+type aliasError = error
+
+func aliasErrorTypeFollowedByDefer(check func() aliasError) error {
+	file, _ := os.Open("example.txt")
+	err := check()
+	if err != nil {
+		return err
+	}
+	defer file.Close()
+
+	fmt.Println("processing file")
+	return nil
+}
+
+// Test 32: Defined type with error as underlying type followed by defer (should NOT warn - type-based detection)
+type definedError error
+
+func definedErrorTypeFollowedByDefer(check func() definedError) error {
+	file, _ := os.Open("example.txt")
+	err := check()
+	if err != nil {
+		return err
+	}
+	defer file.Close()
+
+	fmt.Println("processing file")
+	return nil
+}
+
+// Test 33: Pointer to struct type with pointer receiver Error method followed by defer (should NOT warn - type-based detection)
+func pointerReceiverErrorTypeFollowedByDefer(check func() *customError) error {
+	file, _ := os.Open("example.txt")
+	err := check()
+	if err != nil {
+		return err
+	}
+	defer file.Close()
+
+	fmt.Println("processing file")
+	return nil
+}
+
+// Test 34: Defer inside an if block followed by another statement (SHOULD warn)
+func deferInsideIfFollowedByStatement(cleanup bool) {
+	if cleanup {
+		defer fmt.Println("cleanup") // want "missing newline after block statement"
+		fmt.Println("more work")
+	}
+
+	fmt.Println("done")
+}
+
+// Test 35: Defer as last statement inside an if block (should NOT warn)
+func deferAsLastStatementInsideIf(cleanup bool) {
+	if cleanup {
+		fmt.Println("work")
+		defer fmt.Println("cleanup")
+	}
+
+	fmt.Println("done")
+}
+
+// Test 36: Typed nil error conversion followed by defer (should NOT warn)
+func typedNilErrorFollowedByDefer() error {
+	file, err := os.Open("example.txt")
+	if err != error(nil) {
+		return err
+	}
+	defer file.Close()
+
+	fmt.Println("processing file")
+	return nil
+}
+
+// Test 37: Typed nil pointer conversion with reversed operands followed by defer (should NOT warn)
+func typedNilPointerFollowedByDefer(check func() *customError) error {
+	file, _ := os.Open("example.txt")
+	err := check()
+	if (*customError)(nil) != err {
+		return err
+	}
+	defer file.Close()
+
+	fmt.Println("processing file")
+	return nil
+}
+
+// Test 38: Comparison with a non-nil conversion followed by defer (SHOULD warn)
+func nonNilConversionFollowedByDefer(check func() *customError) error {
+	file, _ := os.Open("example.txt")
+	err := check()
+	if err != (*customError)(err) {
+		return err
+	} // want "missing newline after block statement"
+	defer file.Close()
+
+	fmt.Println("processing file")
+	return nil
+}
+
+func must(err error) {
+	if err != nil {
+		panic(err)
+	}
+}
+
+// Test 39: Error handler call followed by defer (should NOT warn, a call is not a block)
+func errorHandlerCallFollowedByDefer() {
+	file, err := os.Open("example.txt")
+	must(err)
+	defer file.Close()
+
+	fmt.Println("processing file")
+}
+
+// Test 40: Error variable reassigned between error check and defer (SHOULD warn,
+// the intervening statement breaks the exception)
+func errorReassignedBeforeDefer() error {
+	file, err := os.Open("example.txt")
+	if err != nil {
+		return err
+	} // want "missing newline after block statement"
+	err = nil
+	defer file.Close()
+
+	fmt.Println("processing file", err)
+	return nil
+}
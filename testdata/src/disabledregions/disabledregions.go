@@ -0,0 +1,62 @@
+package disabledregions
+
+import "fmt"
+
+func beforeRegion(x int) {
+	if x > 0 {
+		fmt.Println("positive")
+	} // want "missing newline after block statement"
+	fmt.Println("next statement")
+}
+
+//newlineafterblock:disable // legacy code, cleaned up separately
+
+func insideRegion(x int) {
+	if x > 0 {
+		fmt.Println("positive")
+	}
+	fmt.Println("next statement")
+
+	for i := 0; i < x; i++ {
+		fmt.Println(i)
+	}
+	fmt.Println("done")
+
+	switch x {
+	case 1:
+		fmt.Println("one")
+	case 2:
+		fmt.Println("two")
+	}
+}
+
+//newlineafterblock:enable
+
+func afterRegion(x int) {
+	if x > 0 {
+		fmt.Println("positive")
+	} // want "missing newline after block statement"
+	fmt.Println("next statement")
+}
+
+func regionInsideFunction(x int) {
+	//newlineafterblock:disable
+	if x > 0 {
+		fmt.Println("positive")
+	}
+	fmt.Println("next statement")
+	//newlineafterblock:enable
+	if x < 0 {
+		fmt.Println("negative")
+	} // want "missing newline after block statement"
+	fmt.Println("next statement")
+}
+
+// A directive with a space after the slashes is a regular comment.
+func regularComment(x int) {
+	// newlineafterblock:disable
+	if x > 0 {
+		fmt.Println("positive")
+	} // want "missing newline after block statement"
+	fmt.Println("next statement")
+}
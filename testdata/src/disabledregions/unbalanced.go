@@ -0,0 +1,27 @@
+package disabledregions
+
+import "fmt"
+
+func beforeUnbalancedRegion(x int) {
+	if x > 0 {
+		fmt.Println("positive")
+	} // want "missing newline after block statement"
+	fmt.Println("next statement")
+}
+
+// The region is not enabled again, it ends at the end of the file.
+//newlineafterblock:disable
+
+func insideUnbalancedRegion(x int) {
+	if x > 0 {
+		fmt.Println("positive")
+	}
+	fmt.Println("next statement")
+}
+
+func stillInsideUnbalancedRegion(x int) {
+	for i := 0; i < x; i++ {
+		fmt.Println(i)
+	}
+	fmt.Println("done")
+}
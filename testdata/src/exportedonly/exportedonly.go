@@ -0,0 +1,78 @@
+package exportedonly
+
+import "fmt"
+
+// Exported function - analyzed
+func Exported(x int) {
+	if x > 0 {
+		fmt.Println("positive")
+	} // want "missing newline after block statement"
+	fmt.Println("next statement")
+}
+
+// Unexported function - not analyzed with -exported-only
+func unexported(x int) {
+	if x > 0 {
+		fmt.Println("positive")
+	}
+	fmt.Println("next statement")
+}
+
+// Function literals inside an exported function - analyzed
+func ExportedWithClosure(x int) func() {
+	return func() {
+		for i := 0; i < x; i++ {
+			fmt.Println(i)
+		} // want "missing newline after block statement"
+		fmt.Println("done")
+	}
+}
+
+type Server struct{}
+
+// Exported method of an exported type - analyzed
+func (s *Server) Serve(x int) {
+	switch x {
+	case 1:
+		fmt.Println("one") // want "missing newline after case block"
+	case 2:
+		fmt.Println("two")
+	} // want "missing newline after block statement"
+	fmt.Println("served")
+}
+
+// Unexported method of an exported type - not analyzed
+func (s *Server) handle(x int) {
+	if x > 0 {
+		fmt.Println("positive")
+	}
+	fmt.Println("handled")
+}
+
+type Box[T any] struct{ value T }
+
+// Exported method of an exported generic type - analyzed
+func (b Box[T]) Print(ok bool) {
+	if ok {
+		fmt.Println(b.value)
+	} // want "missing newline after block statement"
+	fmt.Println("printed")
+}
+
+type client struct{}
+
+// Exported method of an unexported type - not analyzed
+func (c client) Do(x int) {
+	if x > 0 {
+		fmt.Println("positive")
+	}
+	fmt.Println("done")
+}
+
+// Function literal at package level - not analyzed
+var handler = func(x int) {
+	if x > 0 {
+		fmt.Println("positive")
+	}
+	fmt.Println("handled")
+}
@@ -0,0 +1,83 @@
+package funclitargs
+
+import (
+	"fmt"
+	"sync"
+)
+
+// Test cases for the -check-trailing-funclit-args flag
+
+type group struct {
+	wg sync.WaitGroup
+}
+
+func (g *group) Go(f func() error) {
+	g.wg.Add(1)
+	go func() {
+		defer g.wg.Done()
+
+		_ = f()
+	}()
+}
+
+func trailingClosureFollowedByStatement(g *group) {
+	g.Go(func() error {
+		fmt.Println("work")
+		return nil
+	}) // want "missing newline after block statement"
+	fmt.Println("next statement")
+}
+
+func trailingClosureFollowedByBlankLine(g *group) {
+	g.Go(func() error {
+		fmt.Println("work")
+		return nil
+	})
+
+	fmt.Println("next statement")
+}
+
+func trailingClosureWithMoreArguments(items []int) {
+	forEach(items, func(item int) {
+		fmt.Println(item)
+	}) // want "missing newline after block statement"
+	fmt.Println("next statement")
+}
+
+func consecutiveTrailingClosures(g *group) {
+	g.Go(func() error {
+		return nil
+	}) // want "missing newline after block statement"
+	g.Go(func() error {
+		return nil
+	})
+}
+
+func singleLineTrailingClosure(g *group) {
+	g.Go(func() error { return nil })
+	fmt.Println("next statement")
+}
+
+func closureNotLastArgument(items []int) {
+	apply(func(item int) {
+		fmt.Println(item)
+	}, items)
+	fmt.Println("next statement")
+}
+
+func invokedClosureArgument() {
+	fmt.Println(func() int {
+		return 1
+	}())
+	fmt.Println("next statement")
+}
+
+func forEach(items []int, f func(int)) {
+	for _, item := range items {
+		f(item)
+	}
+}
+
+func apply(f func(int), items []int) {
+	forEach(items, f)
+}
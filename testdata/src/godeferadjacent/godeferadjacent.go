@@ -0,0 +1,66 @@
+package godeferadjacent
+
+import "fmt"
+
+// Test cases for the -allow-go-defer-adjacent flag
+
+func producer(ch chan int) {
+	for i := 0; i < 3; i++ {
+		ch <- i
+	}
+}
+
+func goFollowedByDefer() {
+	ch := make(chan int)
+	go producer(ch)
+	defer close(ch)
+
+	fmt.Println(<-ch)
+}
+
+func deferFollowedByGo() {
+	ch := make(chan int)
+	defer close(ch)
+	go producer(ch)
+
+	fmt.Println(<-ch)
+}
+
+func launchAndCleanupGroup() {
+	ch := make(chan int)
+	done := make(chan struct{})
+	defer close(done)
+	go producer(ch)
+	defer close(ch)
+
+	fmt.Println(<-ch)
+}
+
+func deferFollowedByRegularStatement() {
+	ch := make(chan int)
+	go producer(ch)
+	defer close(ch) // want "missing newline after block statement"
+	fmt.Println(<-ch)
+}
+
+func goFuncLitFollowedByDefer() {
+	ch := make(chan int)
+	go func() {
+		ch <- 1
+	}()
+	defer close(ch)
+
+	fmt.Println(<-ch)
+}
+
+func consecutiveGoFuncLits() {
+	ch := make(chan int)
+	go func() {
+		ch <- 1
+	}() // want "missing newline after block statement"
+	go func() {
+		ch <- 2
+	}()
+
+	fmt.Println(<-ch, <-ch)
+}
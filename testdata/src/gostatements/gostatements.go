@@ -0,0 +1,83 @@
+package gostatements
+
+import (
+	"fmt"
+	"sync"
+)
+
+func worker(ch chan int) {
+	for v := range ch {
+		fmt.Println(v)
+	}
+}
+
+// Multi-line function literal followed by a statement - violation
+func goFuncLitFollowedByStatement() {
+	var wg sync.WaitGroup
+	wg.Add(1)
+	go func() {
+		fmt.Println("working")
+		wg.Done()
+	}() // want "missing newline after block statement"
+	wg.Wait()
+}
+
+// Multi-line function literal with arguments - violation
+func goFuncLitWithArgsFollowedByStatement(ch chan int) {
+	go func(c chan int) {
+		c <- 1
+	}(ch) // want "missing newline after block statement"
+	fmt.Println(<-ch)
+}
+
+// Multi-line function literal followed by a blank line - correct
+func goFuncLitWithBlankLine() {
+	var wg sync.WaitGroup
+	wg.Add(1)
+	go func() {
+		fmt.Println("working")
+		wg.Done()
+	}()
+
+	wg.Wait()
+}
+
+// Multi-line function literal at the end of the function - correct
+func goFuncLitAtFunctionEnd() {
+	go func() {
+		fmt.Println("working")
+	}()
+}
+
+// Consecutive multi-line function literals - violation
+func consecutiveGoFuncLits() {
+	go func() {
+		fmt.Println("first")
+	}() // want "missing newline after block statement"
+	go func() {
+		fmt.Println("second")
+	}()
+}
+
+// Single-line function literal - no violation
+func goSingleLineFuncLit(ch chan int) {
+	go func() { ch <- 1 }()
+	fmt.Println(<-ch)
+}
+
+// Non-literal calls - no violation
+func goNonLiteralCalls(ch chan int) {
+	go worker(ch)
+	go fmt.Println("hello")
+	ch <- 1
+	close(ch)
+}
+
+// Multi-line function literal followed by a comment - violation
+func goFuncLitFollowedByComment() {
+	go func() {
+		fmt.Println("working")
+	}() // want "missing newline after block statement"
+	// Comment directly after the goroutine
+	fmt.Println("next statement")
+}
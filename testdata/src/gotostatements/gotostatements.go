@@ -0,0 +1,74 @@
+package gotostatements
+
+import "fmt"
+
+// Test cases for the -blank-after-goto flag
+
+func gotoFollowedByStatement(x int) {
+	if x > 0 {
+		goto done // want "missing newline after block statement"
+		fmt.Println("unreachable")
+	}
+
+done:
+	fmt.Println("done")
+}
+
+func gotoFollowedByStatementWithBlankLine(x int) {
+	if x > 0 {
+		goto done
+
+		fmt.Println("unreachable")
+	}
+
+done:
+	fmt.Println("done")
+}
+
+func gotoFollowedByLabel(x int) {
+retry:
+	x--
+	if x > 0 {
+		goto retry
+	}
+
+	goto done // want "missing newline after block statement"
+done:
+	fmt.Println("done")
+}
+
+func consecutiveGotos(x int) {
+	if x > 0 {
+		goto first
+		goto second
+	}
+
+first:
+	fmt.Println("first")
+
+second:
+	fmt.Println("second")
+}
+
+func gotoAtEndOfBlock(x int) {
+	if x > 0 {
+		goto done
+	}
+
+done:
+	fmt.Println("done")
+}
+
+func otherBranchStatements(items []int) {
+	for _, item := range items {
+		if item > 10 {
+			break
+		}
+
+		if item < 0 {
+			continue
+		}
+
+		fmt.Println(item)
+	}
+}
@@ -0,0 +1,89 @@
+package lastcaseblock
+
+import "fmt"
+
+// Test cases for the -last-case-block flag
+
+func lastCaseEndsWithBlock(x int) {
+	switch x {
+	case 1:
+		fmt.Println("one")
+
+	default:
+		if x > 10 {
+			fmt.Println("large")
+		} // want "missing newline after block statement"
+	}
+}
+
+func lastCaseEndsWithBlockAndBlankLine(x int) {
+	switch x {
+	case 1:
+		fmt.Println("one")
+
+	default:
+		if x > 10 {
+			fmt.Println("large")
+		}
+
+	}
+}
+
+func lastCaseEndsWithStatement(x int) {
+	switch x {
+	case 1:
+		fmt.Println("one")
+
+	default:
+		fmt.Println("other")
+	}
+}
+
+func singleCaseEndsWithLoop(x int) {
+	switch {
+	case x > 0:
+		for i := 0; i < x; i++ {
+			fmt.Println(i)
+		} // want "missing newline after block statement"
+	}
+}
+
+func typeSwitchLastCaseEndsWithBlock(a any) {
+	switch v := a.(type) {
+	case string:
+		fmt.Println("string:", v)
+
+	case int:
+		if v > 0 {
+			fmt.Println("positive")
+		} // want "missing newline after block statement"
+	}
+}
+
+func lastCaseEndsWithDefer(x int) {
+	switch x {
+	case 1:
+		defer fmt.Println("cleanup")
+	}
+}
+
+func nonLastCaseEndsWithBlock(x int) {
+	switch x {
+	case 1:
+		if x > 0 {
+			fmt.Println("positive")
+		}
+
+	case 2:
+		fmt.Println("two")
+	}
+}
+
+func selectIsNotAffected(ch chan int) {
+	select {
+	case v := <-ch:
+		if v > 0 {
+			fmt.Println("positive")
+		}
+	}
+}
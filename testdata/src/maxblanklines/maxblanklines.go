@@ -0,0 +1,66 @@
+package maxblanklines
+
+import "fmt"
+
+// The tests run with -max-blank-lines=2.
+
+// One blank line after the block - correct
+func oneBlankLine(x int) {
+	if x > 0 {
+		fmt.Println("positive")
+	}
+
+	fmt.Println("next statement")
+}
+
+// Two blank lines after the block - correct
+func twoBlankLines(x int) {
+	if x > 0 {
+		fmt.Println("positive")
+	}
+
+
+	fmt.Println("next statement")
+}
+
+// Three blank lines after the block - violation
+func threeBlankLines(x int) {
+	if x > 0 {
+		fmt.Println("positive")
+	} // want "too many blank lines after block statement"
+
+
+
+	fmt.Println("next statement")
+}
+
+// Four blank lines after the block before a comment - violation
+func fourBlankLinesBeforeComment(x int) {
+	for i := 0; i < x; i++ {
+		fmt.Println(i)
+	} // want "too many blank lines after block statement"
+
+
+
+
+	// Comment after the blank lines
+	fmt.Println("done")
+}
+
+// Missing blank line after the block - still reported
+func missingBlankLine(x int) {
+	if x > 0 {
+		fmt.Println("positive")
+	} // want "missing newline after block statement"
+	fmt.Println("next statement")
+}
+
+// Three blank lines between defers - violation, the defer exception only
+// excuses a missing blank line
+func threeBlankLinesBetweenDefers() {
+	defer fmt.Println("first") // want "too many blank lines after block statement"
+
+
+
+	defer fmt.Println("second")
+}
@@ -0,0 +1,47 @@
+package message
+
+import "fmt"
+
+func ifBlock(x int) {
+	if x > 0 {
+		fmt.Println("positive")
+	} // want "missing newline after if block"
+	fmt.Println("next statement")
+}
+
+func forBlock(x int) {
+	for i := 0; i < x; i++ {
+		fmt.Println(i)
+	} // want "missing newline after for block"
+	fmt.Println("done")
+}
+
+func rangeBlockFollowedByComment(items []int) {
+	for _, item := range items {
+		fmt.Println(item)
+	} // want "missing newline after range block"
+	// Comment directly after the block
+	fmt.Println("done")
+}
+
+func switchBlock(x int) {
+	switch x {
+	case 1:
+		fmt.Println("one") // want "missing newline after case block"
+	case 2:
+		fmt.Println("two")
+	} // want "missing newline after switch block"
+	fmt.Println("done")
+}
+
+func funcLiteral() {
+	f := func() {
+		fmt.Println("called")
+	} // want "missing newline after func block"
+	f()
+}
+
+func deferStatement() {
+	defer fmt.Println("done") // want "missing newline after defer block"
+	fmt.Println("working")
+}
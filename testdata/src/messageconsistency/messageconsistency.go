@@ -0,0 +1,27 @@
+package messageconsistency
+
+import "fmt"
+
+// With -case-consistency, the -message template is also used for the
+// inconsistent blank lines between case blocks, followed by the default message.
+func inconsistentCases(x int) {
+	switch x {
+	case 1:
+		fmt.Println("one")
+
+	case 2:
+		fmt.Println("two") // want "missing newline after case block: inconsistent blank lines between case blocks"
+	default:
+		fmt.Println("other")
+	}
+}
+
+// Consistently compact switch statements are not reported.
+func compactCases(x int) {
+	switch x {
+	case 1:
+		fmt.Println("one")
+	default:
+		fmt.Println("other")
+	}
+}
@@ -0,0 +1,31 @@
+package messagedetails
+
+import "fmt"
+
+// The -message template is used for all diagnostics, the diagnostics other than
+// missing blank lines after blocks append their default message.
+// This file is intentionally not gofmt formatted.
+
+func blockBefore() {
+	x := 5
+	if x > 0 { // want "missing newline after if block: missing newline before block statement"
+		fmt.Println("positive")
+	}
+
+	fmt.Println("next statement")
+}
+
+func tooManyBlankLines(items []int) {
+	for _, item := range items {
+		fmt.Println(item)
+	} // want "missing newline after range block: too many blank lines after block statement"
+
+
+	fmt.Println("next statement")
+}
+
+func sameLineStatement(x int) {
+	if x > 0 {
+		fmt.Println("positive")
+	}; fmt.Println("next statement") // want "missing newline after if block: statement on the same line as the end of block statement"
+}
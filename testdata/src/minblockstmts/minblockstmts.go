@@ -0,0 +1,116 @@
+package minblockstmts
+
+import "fmt"
+
+// Test cases for the -min-block-stmts flag, configured as if=2,range=3,switch=2,func=2
+
+func ifBelowThreshold(x int) {
+	if x > 0 {
+		fmt.Println("positive")
+	}
+	fmt.Println("next statement")
+}
+
+func ifAtThreshold(x int) {
+	if x > 0 {
+		fmt.Println("positive")
+		fmt.Println("still positive")
+	} // want "missing newline after block statement"
+	fmt.Println("next statement")
+}
+
+func emptyIfBelowThreshold(x int) {
+	if x > 0 {
+	}
+	fmt.Println("next statement")
+}
+
+func ifElseUsesIfBody(x int) {
+	if x > 0 {
+		fmt.Println("positive")
+	} else {
+		fmt.Println("not positive")
+		fmt.Println("still not positive")
+	}
+	fmt.Println("next statement")
+}
+
+func rangeBelowThreshold(items []int) {
+	for _, item := range items {
+		fmt.Println(item)
+		fmt.Println(item * 2)
+	}
+	fmt.Println("next statement")
+}
+
+func rangeAtThreshold(items []int) {
+	for _, item := range items {
+		fmt.Println(item)
+		fmt.Println(item * 2)
+		fmt.Println(item * 3)
+	} // want "missing newline after block statement"
+	fmt.Println("next statement")
+}
+
+func forWithoutThreshold() {
+	for i := 0; i < 3; i++ {
+		fmt.Println(i)
+	} // want "missing newline after block statement"
+	fmt.Println("next statement")
+}
+
+func switchCountsCaseClauses(x int) {
+	switch x {
+	case 1:
+		fmt.Println("one")
+		fmt.Println("still one")
+	}
+	fmt.Println("next statement")
+}
+
+func switchAtThreshold(x int) {
+	switch x {
+	case 1:
+		fmt.Println("one")
+
+	default:
+		fmt.Println("other")
+	} // want "missing newline after block statement"
+	fmt.Println("next statement")
+}
+
+func typeSwitchBelowThreshold(v any) {
+	switch v.(type) {
+	case int:
+		fmt.Println("int")
+	}
+	fmt.Println("next statement")
+}
+
+func funcLitBelowThreshold() {
+	f := func() {
+		fmt.Println("closure")
+	}
+	f()
+}
+
+func funcLitAtThreshold() {
+	f := func() {
+		fmt.Println("closure")
+		fmt.Println("still closure")
+	} // want "missing newline after block statement"
+	f()
+}
+
+func selectWithoutThreshold(ch chan int) {
+	select {
+	case v := <-ch:
+		fmt.Println(v)
+	} // want "missing newline after block statement"
+	fmt.Println("next statement")
+}
+
+func deferUnaffected() {
+	defer fmt.Println("deferred") // want "missing newline after block statement"
+	fmt.Println("next statement")
+}
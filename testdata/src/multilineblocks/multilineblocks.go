@@ -0,0 +1,64 @@
+package multilineblocks
+
+import "fmt"
+
+// Test cases for the -multiline-blocks-only flag
+// This file is intentionally not gofmt formatted.
+
+func oneLineIf(x int) {
+	if x > 0 { fmt.Println("positive") }
+	fmt.Println("next statement")
+}
+
+func multiLineIf(x int) {
+	if x > 0 {
+		fmt.Println("positive")
+	} // want "missing newline after block statement"
+	fmt.Println("next statement")
+}
+
+func oneLineIfElse(x int) {
+	if x > 0 { fmt.Println("positive") } else { fmt.Println("not positive") }
+	fmt.Println("next statement")
+}
+
+func oneLineIfWithMultiLineElse(x int) {
+	if x > 0 { fmt.Println("positive") } else {
+		fmt.Println("not positive")
+	} // want "missing newline after block statement"
+	fmt.Println("next statement")
+}
+
+func oneLineForLoop() {
+	for i := 0; i < 3; i++ { fmt.Println(i) }
+	fmt.Println("next statement")
+}
+
+func oneLineRangeLoop(items []int) {
+	for _, item := range items { fmt.Println(item) }
+	fmt.Println("next statement")
+}
+
+func oneLineFuncLit() {
+	f := func() { fmt.Println("closure") }
+	f()
+}
+
+func multiLineFuncLit() {
+	f := func() {
+		fmt.Println("closure")
+	} // want "missing newline after block statement"
+	f()
+}
+
+func oneLineBlockWithTrailingComment(x int) {
+	if x > 0 {
+		if x > 1 { fmt.Println("greater than one") }
+		// Comment directly after the one-line block
+	}
+}
+
+func deferIsNotAffected() {
+	defer fmt.Println("cleanup") // want "missing newline after block statement"
+	fmt.Println("next statement")
+}
@@ -0,0 +1,58 @@
+package namedfunclit
+
+import "fmt"
+
+// Test cases for the -named-funclit-only flag
+
+type handlers struct {
+	onDone func()
+}
+
+func namedAssignment() {
+	f := func() {
+		fmt.Println("closure")
+	} // want "missing newline after block statement"
+	f()
+}
+
+func namedDeclaration() {
+	var f = func() {
+		fmt.Println("closure")
+	} // want "missing newline after block statement"
+	f()
+}
+
+func blankAssignment() {
+	_ = func() {
+		fmt.Println("closure")
+	}
+	fmt.Println("next statement")
+}
+
+func blankDeclaration() {
+	var _ = func() {
+		fmt.Println("closure")
+	}
+	fmt.Println("next statement")
+}
+
+func indexAssignment(callbacks map[string]func()) {
+	callbacks["done"] = func() {
+		fmt.Println("done")
+	}
+	fmt.Println("next statement")
+}
+
+func fieldAssignment(h *handlers) {
+	h.onDone = func() {
+		fmt.Println("done")
+	}
+	fmt.Println("next statement")
+}
+
+func multiAssignmentWithNamedClosure() {
+	_, f := 1, func() {
+		fmt.Println("closure")
+	} // want "missing newline after block statement"
+	f()
+}
@@ -0,0 +1,60 @@
+package nocaseclauses
+
+import "fmt"
+
+// Case blocks without blank lines in between - no violation with -case-clauses=false
+func switchWithoutNewlineBetweenCases(x int) {
+	switch x {
+	case 1:
+		fmt.Println("one")
+	case 2:
+		fmt.Println("two")
+	default:
+		fmt.Println("other")
+	}
+}
+
+// Type switch case blocks without blank lines in between - no violation
+func typeSwitchWithoutNewlineBetweenCases(a any) {
+	switch v := a.(type) {
+	case string:
+		fmt.Println("string:", v)
+	// Comment directly after the case body
+	case int:
+		fmt.Println("int:", v)
+	}
+}
+
+// Select case blocks without blank lines in between - no violation
+func selectWithoutNewlineBetweenCases(ch1, ch2 chan int) {
+	select {
+	case v := <-ch1:
+		fmt.Println(v)
+	case v := <-ch2:
+		fmt.Println(v)
+	}
+}
+
+// Blocks inside case bodies are still checked by the after-block rule
+func blockInsideCaseBody(x int) {
+	switch x {
+	case 1:
+		if x > 0 {
+			fmt.Println("positive")
+		} // want "missing newline after block statement"
+		fmt.Println("one")
+	case 2:
+		fmt.Println("two")
+	}
+}
+
+// The switch itself is still checked by the after-block rule
+func switchFollowedByStatement(x int) {
+	switch x {
+	case 1:
+		fmt.Println("one")
+	case 2:
+		fmt.Println("two")
+	} // want "missing newline after block statement"
+	fmt.Println("done")
+}
@@ -0,0 +1,13 @@
+package noexclude
+
+import "fmt"
+
+// Test cases for the -no-exclude flag
+
+func notExcluded() {
+	x := 5
+	if x > 0 {
+		fmt.Println("positive")
+	} // want "missing newline after block statement"
+	fmt.Println("next statement")
+}
@@ -0,0 +1,13 @@
+package noexclude
+
+import "fmt"
+
+// This file matches the exclude pattern used in the tests, -no-exclude causes
+// it to be analyzed anyway.
+
+func excludedButAnalyzed() {
+	for i := 0; i < 3; i++ {
+		fmt.Println(i)
+	} // want "missing newline after block statement"
+	fmt.Println("next statement")
+}
@@ -0,0 +1,67 @@
+package nolint
+
+import "fmt"
+
+// Bare nolint directive on the closing brace line - suppressed
+func bareNolint(x int) {
+	if x > 0 {
+		fmt.Println("positive")
+	} //nolint
+	fmt.Println("next statement")
+}
+
+// Nolint directive for this linter - suppressed
+func nolintForLinter(x int) {
+	if x > 0 {
+		fmt.Println("positive")
+	} //nolint:newlineafterblock
+	fmt.Println("next statement")
+}
+
+// Nolint directive listing several linters with an explanation - suppressed
+func nolintForSeveralLinters(x int) {
+	for i := 0; i < x; i++ {
+		fmt.Println(i)
+	} //nolint:lll,newlineafterblock // keep the loop and its summary together
+	fmt.Println("done")
+}
+
+// Nolint directive with the golangci-lint name of this linter - suppressed
+func nolintForGolangciName(x int) {
+	if x > 0 {
+		fmt.Println("positive")
+	} //nolint:newline-after-block
+	fmt.Println("next statement")
+}
+
+// Nolint directive for another linter - not suppressed
+func nolintForOtherLinter(x int) {
+	if x > 0 {
+		fmt.Println("positive")
+	} //nolint:lll // want "missing newline after block statement"
+	fmt.Println("next statement")
+}
+
+// Nolint directive on another line - not suppressed
+func nolintOnOtherLine(x int) {
+	if x > 0 { //nolint
+		fmt.Println("positive")
+	} // want "missing newline after block statement"
+	fmt.Println("next statement")
+}
+
+// Comment that only starts like a nolint directive - not suppressed
+func nolintLookalike(x int) {
+	if x > 0 {
+		fmt.Println("positive")
+	} //nolintx // want "missing newline after block statement"
+	fmt.Println("next statement")
+}
+
+// Comment with a space before nolint is not a directive - not suppressed
+func nolintWithSpace(x int) {
+	if x > 0 {
+		fmt.Println("positive")
+	} // nolint // want "missing newline after block statement"
+	fmt.Println("next statement")
+}
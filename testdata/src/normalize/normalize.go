@@ -0,0 +1,109 @@
+package normalize
+
+import "fmt"
+
+// Test cases for the -normalize flag
+
+func zeroBlankLines() {
+	x := 5
+	if x > 0 {
+		fmt.Println("positive")
+	} // want "missing newline after block statement"
+	fmt.Println("next statement")
+}
+
+func oneBlankLine() {
+	x := 5
+	if x > 0 {
+		fmt.Println("positive")
+	}
+
+	fmt.Println("next statement")
+}
+
+func threeBlankLines() {
+	x := 5
+	if x > 0 {
+		fmt.Println("positive")
+	} // want "too many blank lines after block statement"
+
+
+
+	fmt.Println("next statement")
+}
+
+func twoBlankLinesBeforeComment() {
+	for i := 0; i < 3; i++ {
+		fmt.Println(i)
+	} // want "too many blank lines after block statement"
+
+
+	// Comment after the loop
+	fmt.Println("next statement")
+}
+
+func blankLinesAfterCommentAreNotChecked() {
+	for i := 0; i < 3; i++ {
+		fmt.Println(i)
+	}
+
+	// Comment after the loop
+
+
+	fmt.Println("next statement")
+}
+
+func threeBlankLinesAfterInlineComment() {
+	x := 5
+	if x > 0 {
+		fmt.Println("positive")
+	} /* inline comment */ // want "too many blank lines after block statement"
+
+
+
+	fmt.Println("next statement")
+}
+
+func blankLinesAfterNonBlock() {
+	x := 5
+
+
+	fmt.Println(x)
+}
+
+func gluedMultiLineInlineComment() {
+	x := 5
+	if x > 0 {
+		fmt.Println("positive")
+	}/* glued comment
+	spanning two lines */
+
+	fmt.Println("next statement")
+}
+
+type closer struct{}
+
+func (closer) Close() error { return nil }
+
+func open() (closer, error) { return closer{}, nil }
+
+func threeBlankLinesBeforeDeferAfterErrorCheck() error {
+	f, err := open()
+	if err != nil {
+		return err
+	} // want "too many blank lines after block statement"
+
+
+
+	defer f.Close()
+
+	return nil
+}
+
+func threeBlankLinesBetweenDefers() {
+	defer fmt.Println("first") // want "too many blank lines after block statement"
+
+
+
+	defer fmt.Println("second")
+}
@@ -0,0 +1,14 @@
+package packagecomment // Code generated by hand for the tests. DO NOT EDIT.
+
+import "fmt"
+
+// A trailing comment on the package clause is not a file header, the file is
+// analyzed as usual.
+
+func trailingCommentBlock() {
+	x := 5
+	if x > 0 {
+		fmt.Println("positive")
+	} // want "missing newline after block statement"
+	fmt.Println("next statement")
+}
@@ -0,0 +1,49 @@
+package main
+
+import "fmt"
+
+// Test cases for the -relax-main-init flag
+
+var debug bool
+
+func init() {
+	if debug {
+		fmt.Println("debug enabled")
+	}
+	fmt.Println("init done")
+}
+
+func main() {
+	for i := 0; i < 3; i++ {
+		fmt.Println(i)
+	}
+	fmt.Println("setup done")
+
+	run := func() {
+		if debug {
+			fmt.Println("running")
+		}
+		fmt.Println("run done")
+	}
+	run()
+
+	setup()
+	server{}.main()
+}
+
+func setup() {
+	if debug {
+		fmt.Println("setup")
+	} // want "missing newline after block statement"
+	fmt.Println("setup done")
+}
+
+type server struct{}
+
+// main methods are not relaxed, only the main function is.
+func (s server) main() {
+	if debug {
+		fmt.Println("server")
+	} // want "missing newline after block statement"
+	fmt.Println("server done")
+}
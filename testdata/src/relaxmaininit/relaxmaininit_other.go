@@ -0,0 +1,11 @@
+package main
+
+import "fmt"
+
+// Every init function is relaxed, also if there are several of them.
+func init() {
+	for i := 0; i < 3; i++ {
+		fmt.Println(i)
+	}
+	fmt.Println("second init done")
+}
@@ -0,0 +1,77 @@
+package reportatnext
+
+import "fmt"
+
+// Block followed by a statement - reported at the statement
+func blockFollowedByStatement(x int) {
+	if x > 0 {
+		fmt.Println("positive")
+	}
+	fmt.Println("next statement") // want "missing newline after block statement"
+}
+
+// Block with an inline comment followed by a statement - reported at the statement
+func blockWithInlineCommentFollowedByStatement(x int) {
+	for i := 0; i < x; i++ {
+		fmt.Println(i)
+	} // inline comment
+	fmt.Println("next statement") // want "missing newline after block statement"
+}
+
+// Block followed by a comment - reported at the comment
+func blockFollowedByComment(x int) {
+	if x > 0 {
+		fmt.Println("positive")
+	}
+	// Comment directly after the block // want "missing newline after block statement"
+	fmt.Println("next statement")
+}
+
+// Block followed by a trailing comment - reported at the comment
+func blockFollowedByTrailingComment(x int) {
+	if x > 0 {
+		fmt.Println("positive")
+	}
+	// Trailing comment // want "missing newline after block statement"
+}
+
+// Case blocks without blank lines - reported at the next case
+func switchWithoutNewlineBetweenCases(x int) {
+	switch x {
+	case 1:
+		fmt.Println("one")
+	case 2: // want "missing newline after case block"
+		fmt.Println("two")
+	}
+}
+
+// Defer followed by a statement - reported at the statement
+func deferFollowedByStatement() {
+	defer fmt.Println("done")
+	fmt.Println("working") // want "missing newline after block statement"
+}
+
+// Block followed by a blank line - correct
+func blockWithBlankLine(x int) {
+	if x > 0 {
+		fmt.Println("positive")
+	}
+
+	fmt.Println("next statement")
+}
+
+// Suppressed by a directive on the line of the closing brace - not reported
+func nolintOnBlockEnd(x int) {
+	if x > 0 {
+		fmt.Println("positive")
+	} //nolint:newlineafterblock // keep the block and the summary together
+	fmt.Println("next statement")
+}
+
+// Suppressed by a directive on the reported line - not reported
+func nolintOnNextLine(x int) {
+	if x > 0 {
+		fmt.Println("positive")
+	}
+	fmt.Println("next statement") //nolint:newlineafterblock
+}
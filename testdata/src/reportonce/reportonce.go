@@ -0,0 +1,42 @@
+package reportonce
+
+import "fmt"
+
+// Test cases for the -report-once-per-block flag
+
+func blockEndingCaseFollowedByComment(x int) {
+	switch x {
+	case 1:
+		if x > 0 {
+			fmt.Println("positive")
+		} // want "missing newline after case block"
+	// Comment directly before the next case
+	case 2:
+		fmt.Println("two")
+	}
+}
+
+func blockFollowedByCommentThenStatement() {
+	x := 5
+	if x > 0 {
+		fmt.Println("positive")
+	} // want "missing newline after block statement"
+	// Comment directly after the block
+	fmt.Println("next statement")
+}
+
+func separateBlocksAreReportedIndividually(x int) {
+	switch x {
+	case 1:
+		if x > 0 {
+			fmt.Println("positive")
+		} // want "missing newline after case block"
+	// Comment directly before the next case
+	case 2:
+		for i := 0; i < x; i++ {
+			fmt.Println(i)
+		} // want "missing newline after case block"
+	case 3:
+		fmt.Println("three")
+	}
+}
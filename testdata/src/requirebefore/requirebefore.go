@@ -0,0 +1,164 @@
+package requirebefore
+
+import "fmt"
+
+// If statement directly after an assignment - violation
+func ifAfterAssignment() {
+	x := 5
+	if x > 0 { // want "missing newline before block statement"
+		fmt.Println("positive")
+	}
+}
+
+// For loop directly after an assignment - violation
+func forAfterAssignment() {
+	sum := 0
+	for i := 0; i < 3; i++ { // want "missing newline before block statement"
+		sum += i
+	}
+
+	fmt.Println(sum)
+}
+
+// Range loop directly after an assignment - violation
+func rangeAfterAssignment() {
+	items := []int{1, 2, 3}
+	for _, item := range items { // want "missing newline before block statement"
+		fmt.Println(item)
+	}
+}
+
+// Switch statement directly after an assignment - violation
+func switchAfterAssignment() {
+	x := 2
+	switch x { // want "missing newline before block statement"
+	case 1:
+		fmt.Println("one")
+
+	default:
+		fmt.Println("other")
+	}
+}
+
+// If-else-if chain directly after an assignment - violation
+func ifElseChainAfterAssignment() {
+	x := 5
+	if x > 0 { // want "missing newline before block statement"
+		fmt.Println("positive")
+	} else if x < 0 {
+		fmt.Println("negative")
+	} else {
+		fmt.Println("zero")
+	}
+}
+
+// Blocks preceded by a blank line - correct
+func blocksWithBlankLineBefore() {
+	x := 5
+
+	if x > 0 {
+		fmt.Println("positive")
+	}
+
+	y := 3
+
+	for i := 0; i < y; i++ {
+		fmt.Println(i)
+	}
+}
+
+// Block as first statement of its parent block - no violation
+func blockAsFirstStatement(x int) {
+	if x > 0 {
+		fmt.Println("positive")
+	}
+}
+
+// Block directly after a block - only reported by the after-block rule
+func blockAfterBlock(x int) {
+	if x > 0 {
+		fmt.Println("positive")
+	} // want "missing newline after block statement"
+	for i := 0; i < x; i++ {
+		fmt.Println(i)
+	}
+}
+
+// Leading comment directly above the block separates it - correct
+func leadingCommentAfterAssignment() {
+	x := 5
+	// Check whether x is positive.
+	if x > 0 {
+		fmt.Println("positive")
+	}
+}
+
+// Leading comment preceded by a blank line - correct
+func leadingCommentWithBlankLineBefore() {
+	x := 5
+
+	// Check whether x is positive.
+	if x > 0 {
+		fmt.Println("positive")
+	}
+}
+
+// Inline comment on the previous statement - violation
+func inlineCommentOnPreviousStatement() {
+	x := 5 // the value
+	if x > 0 { // want "missing newline before block statement"
+		fmt.Println("positive")
+	}
+}
+
+// Defer and goto statements are not block statements - no violation
+func deferAfterAssignment(ch chan int) {
+	x := 5
+	defer close(ch)
+
+	fmt.Println(x)
+}
+
+// Multi-line previous statement - violation
+func blockAfterMultiLineStatement() {
+	values := []int{
+		1,
+		2,
+	}
+	for _, v := range values { // want "missing newline before block statement"
+		fmt.Println(v)
+	}
+}
+
+// Labeled loop directly after an assignment - violation
+func labeledLoopAfterAssignment(items []int) {
+	found := false
+Loop: // want "missing newline before block statement"
+	for _, item := range items {
+		if item < 0 {
+			found = true
+			break Loop
+		}
+	}
+
+	fmt.Println(found)
+}
+
+// Doc commented function with a leading comment above its first block - correct
+func docCommentedFirstBlock(x int) {
+	// Only positive values are printed.
+	if x > 0 {
+		fmt.Println("positive")
+	}
+}
+
+// Block after a statement and a leading comment - correct
+func commentedBlockAfterStatement(items []int) {
+	total := 0
+	// Sum up all items.
+	for _, item := range items {
+		total += item
+	}
+
+	fmt.Println(total)
+}
@@ -0,0 +1,47 @@
+package samelinestatement
+
+import "fmt"
+
+// Test cases for the -flag-same-line-statement flag
+// This file is intentionally not gofmt formatted.
+
+func statementOnSameLineAsBrace() {
+	x := 5
+	if x > 0 {
+		fmt.Println("positive")
+	}; fmt.Println("next statement") // want "statement on the same line as the end of block statement"
+}
+
+func nestedStatementOnSameLineAsBrace(items []int) {
+	for _, item := range items {
+		if item > 0 {
+			fmt.Println(item)
+		}; fmt.Println("next item") // want "statement on the same line as the end of block statement"
+	}
+}
+
+func deferOnSameLineAsStatement() {
+	defer fmt.Println("cleanup"); fmt.Println("next statement") // want "statement on the same line as the end of block statement"
+}
+
+func singleLineBlockFollowedBySameLineStatement() {
+	x := 5
+	if x > 0 { fmt.Println("positive") }; fmt.Println("next statement") // want "statement on the same line as the end of block statement"
+}
+
+func statementOnNextLine() {
+	x := 5
+	if x > 0 {
+		fmt.Println("positive")
+	} // want "missing newline after block statement"
+	fmt.Println("next statement")
+}
+
+func semicolonWithoutStatement() {
+	x := 5
+	if x > 0 {
+		fmt.Println("positive")
+	};
+
+	fmt.Println("next statement")
+}
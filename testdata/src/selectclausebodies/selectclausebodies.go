@@ -0,0 +1,47 @@
+package selectclausebodies
+
+import "fmt"
+
+// Test cases for the -select-clause-bodies flag
+
+// Block ending the last select case followed by a comment before the closing brace
+func selectLastCaseBlockFollowedByComment(ch chan int, x int) {
+	select {
+	case v := <-ch:
+		fmt.Println(v)
+
+	default:
+		if x > 0 {
+			fmt.Println("positive")
+		} // want "missing newline after block statement"
+		// Trailing comment of the last case
+	}
+}
+
+// Block in a select case followed by a statement
+func selectCaseBlockFollowedByStatement(ch chan int) {
+	select {
+	case v := <-ch:
+		if v > 0 {
+			fmt.Println("positive")
+		} // want "missing newline after block statement"
+		fmt.Println(v)
+
+	default:
+	}
+}
+
+// Block ending the last case followed by a blank line and a comment - correct
+func selectLastCaseBlockWithBlankLineBeforeComment(ch chan int, x int) {
+	select {
+	case v := <-ch:
+		fmt.Println(v)
+
+	default:
+		if x > 0 {
+			fmt.Println("positive")
+		}
+
+		// Trailing comment of the last case
+	}
+}
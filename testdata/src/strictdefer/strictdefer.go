@@ -0,0 +1,55 @@
+package strictdefer
+
+import (
+	"errors"
+	"fmt"
+	"os"
+)
+
+// Defer immediately after an error check - violation with -strict-defer
+func deferAfterErrorCheck() {
+	f, err := os.Open("file.txt")
+	if err != nil {
+		return
+	} // want "missing newline after block statement"
+	defer f.Close()
+
+	fmt.Println("working")
+}
+
+// Consecutive defer statements - violation with -strict-defer
+func consecutiveDefers() {
+	defer fmt.Println("first") // want "missing newline after block statement"
+	defer fmt.Println("second")
+
+	fmt.Println("working")
+}
+
+// Defer after an error check with a custom error type - violation with -strict-defer
+func deferAfterCustomErrorCheck() {
+	err := errors.New("failed")
+	if err != nil {
+		fmt.Println(err)
+	} // want "missing newline after block statement"
+	defer fmt.Println("cleanup")
+}
+
+// Separated defer statements - correct
+func separatedDefers() {
+	f, err := os.Open("file.txt")
+	if err != nil {
+		return
+	}
+
+	defer f.Close()
+
+	defer fmt.Println("done")
+
+	fmt.Println("working")
+}
+
+// Defer followed by a non-defer statement - violation as without -strict-defer
+func deferFollowedByStatement() {
+	defer fmt.Println("done") // want "missing newline after block statement"
+	fmt.Println("working")
+}
@@ -0,0 +1,19 @@
+package strictguardspacing
+
+import "fmt"
+
+// The test also sets -min-block-stmts if=2, guards are reported regardless.
+
+func singleStatementGuard(x int) {
+	if x < 0 {
+		return
+	} // want "missing newline after block statement"
+	fmt.Println(x)
+}
+
+func singleStatementIfWithoutGuard(x int) {
+	if x < 0 {
+		fmt.Println("negative")
+	}
+	fmt.Println(x)
+}
@@ -0,0 +1,62 @@
+package strictguardspacing
+
+import (
+	"fmt"
+	"os"
+)
+
+// Defer immediately after an error check guard - violation with -strict-guard-spacing
+func deferAfterErrorCheckGuard() error {
+	f, err := os.Open("file.txt")
+	if err != nil {
+		return err
+	} // want "missing newline after block statement"
+	defer f.Close()
+
+	fmt.Println("working")
+
+	return nil
+}
+
+// Defer immediately after an error check guard with panic - violation with -strict-guard-spacing
+func deferAfterPanicGuard() {
+	f, err := os.Open("file.txt")
+	if err != nil {
+		panic(err)
+	} // want "missing newline after block statement"
+	defer f.Close()
+
+	fmt.Println("working")
+}
+
+// Defer after an error check which is not a guard - the defer exception still applies
+func deferAfterErrorCheckWithoutGuard() {
+	f, err := os.Open("file.txt")
+	if err != nil {
+		fmt.Println(err)
+		os.Exit(1)
+	}
+	defer f.Close()
+
+	fmt.Println("working")
+}
+
+// Guard followed by a blank line - correct
+func guardWithBlankLine() error {
+	f, err := os.Open("file.txt")
+	if err != nil {
+		return err
+	}
+
+	defer f.Close()
+
+	return nil
+}
+
+// Guard followed by a statement - violation as without -strict-guard-spacing
+func guardFollowedByStatement(x int) {
+	if x < 0 {
+		return
+	} // want "missing newline after block statement"
+	fmt.Println(x)
+}
@@ -132,3 +132,72 @@ func sliceLiteralBeforeIf() {
 	} // want "missing newline after block statement"
 	fmt.Println(s)
 }
+
+type Hooks struct {
+	OnStart func()
+	OnStop  func()
+}
+
+// Closures stored in composite literal fields are still checked internally,
+// while the composite literal itself remains exempt.
+func funcLiteralInsideStructLiteral() {
+	x := 5
+	hooks := Hooks{
+		OnStart: func() {
+			if x > 0 {
+				fmt.Println("positive")
+			} // want "missing newline after block statement"
+			fmt.Println("started")
+		},
+		OnStop: func() {
+			for i := 0; i < x; i++ {
+				fmt.Println(i)
+			}
+
+			fmt.Println("stopped")
+		},
+	}
+	hooks.OnStart()
+}
+
+func funcLiteralInsideMapLiteral() {
+	handlers := map[string]func(int){
+		"print": func(x int) {
+			switch x {
+			case 1:
+				fmt.Println("one")
+
+			default:
+				fmt.Println("other")
+			} // want "missing newline after block statement"
+			fmt.Println("done")
+		},
+	}
+	handlers["print"](1)
+}
+
+func funcLiteralInsideSliceLiteral() {
+	steps := []func(){
+		func() {
+			if true {
+				fmt.Println("step")
+			} // want "missing newline after block statement"
+			fmt.Println("next step")
+		},
+	}
+	steps[0]()
+}
+
+// Anonymous struct with a func field called right away, the assignment is a
+// composite literal (exempt) while the closure body is still checked.
+func funcLiteralInsideAnonymousStruct(y bool) {
+	x := struct{ F func() }{
+		F: func() {
+			if y {
+				fmt.Println("y")
+			} // want "missing newline after block statement"
+			fmt.Println("called")
+		},
+	}
+	x.F()
+}
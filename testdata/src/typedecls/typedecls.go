@@ -0,0 +1,67 @@
+package typedecls
+
+import "fmt"
+
+// Inline interface type declaration followed by a statement - violation with -flag-type-decls
+func interfaceTypeDecl() {
+	type greeter interface {
+		Greet() string
+	} // want "missing newline after block statement"
+	var g greeter
+	fmt.Println(g)
+}
+
+// Inline struct type declaration followed by a statement - violation with -flag-type-decls
+func structTypeDecl() {
+	type point struct {
+		x, y int
+	} // want "missing newline after block statement"
+	p := point{x: 1, y: 2}
+	fmt.Println(p)
+}
+
+// Grouped type declaration followed by a statement - violation with -flag-type-decls
+func groupedTypeDecl() {
+	type (
+		celsius    float64
+		fahrenheit float64
+	) // want "missing newline after block statement"
+	fmt.Println(celsius(1), fahrenheit(2))
+}
+
+// Inline type declaration followed by a comment - violation with -flag-type-decls
+func typeDeclFollowedByComment() {
+	type point struct {
+		x, y int
+	} // want "missing newline after block statement"
+	// Comment directly after the type declaration
+	fmt.Println(point{})
+}
+
+// Inline type declaration followed by a blank line - correct
+func typeDeclWithBlankLine() {
+	type greeter interface {
+		Greet() string
+	}
+
+	var g greeter
+	fmt.Println(g)
+}
+
+// Single-line type declarations - no violation
+func singleLineTypeDecls() {
+	type celsius float64
+	type empty struct{}
+	fmt.Println(celsius(1), empty{})
+}
+
+// Composite literals of struct types are still no blocks - no violation
+func structLiteral() {
+	p := struct {
+		x, y int
+	}{
+		x: 1,
+		y: 2,
+	}
+	fmt.Println(p)
+}